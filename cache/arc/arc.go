@@ -0,0 +1,239 @@
+package arc
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Cache ARC (Adaptive Replacement Cache) structure.
+//
+// T1 holds keys seen once recently and T2 keys seen at least twice. B1 and B2 are
+// ghost lists remembering the keys recently evicted from T1 and T2. A hit in B1 means
+// T1 was too small and grows its target size p, a hit in B2 shrinks it, so the cache
+// adapts between recency and frequency without tuning.
+type Cache struct {
+	capacity int
+	p        int        // target size of T1
+	t1       *list.List // resident, seen once, most recent first
+	t2       *list.List // resident, seen at least twice, most recent first
+	b1       *list.List // ghosts evicted from T1, most recent first
+	b2       *list.List // ghosts evicted from T2, most recent first
+	cache    map[any]*list.Element
+	mutex    sync.RWMutex
+}
+
+// entry cache entry, ghosts keep the key only
+type entry struct {
+	key   any
+	value any
+	list  *list.List // the list holding the entry
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new ARC cache
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		cache:    make(map[any]*list.Element),
+	}
+}
+
+// Get retrieves a value from the cache and promotes it to the frequency list
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.resident(key); ok {
+		e := element.Value.(*entry)
+		c.moveToFront(element, c.t2)
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.cache[key]
+	if ok {
+		e := element.Value.(*entry)
+		switch e.list {
+		case c.t1, c.t2:
+			// Resident hit: update and promote to the frequency list
+			e.value = value
+			c.moveToFront(element, c.t2)
+			return
+		case c.b1:
+			// T1 was evicted too early, favour recency
+			c.p = min(c.capacity, c.p+max(c.b2.Len()/c.b1.Len(), 1))
+			c.makeRoom(false)
+		case c.b2:
+			// T2 was evicted too early, favour frequency
+			c.p = max(0, c.p-max(c.b1.Len()/c.b2.Len(), 1))
+			c.makeRoom(true)
+		}
+		// A ghost hit comes back straight into the frequency list
+		e.value = value
+		c.moveToFront(element, c.t2)
+		return
+	}
+
+	// A key not seen recently
+	if c.t1.Len()+c.b1.Len() >= c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.removeBack(c.b1)
+			c.makeRoom(false)
+		} else {
+			c.removeBack(c.t1)
+		}
+	} else if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= c.capacity {
+		if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= 2*c.capacity {
+			c.removeBack(c.b2)
+		}
+		c.makeRoom(false)
+	}
+
+	e := &entry{key: key, value: value, list: c.t1}
+	c.cache[key] = c.t1.PushFront(e)
+}
+
+// makeRoom evicts one resident entry into its ghost list if the cache is full.
+// inB2 reports whether the key being added was found in B2.
+func (c *Cache) makeRoom(inB2 bool) {
+	if c.t1.Len()+c.t2.Len() < c.capacity {
+		return
+	}
+	if c.t1.Len() > 0 && (c.t1.Len() > c.p || (inB2 && c.t1.Len() == c.p) || c.t2.Len() == 0) {
+		c.demote(c.t1.Back(), c.b1)
+	} else if c.t2.Len() > 0 {
+		c.demote(c.t2.Back(), c.b2)
+	}
+}
+
+// demote turns a resident entry into a ghost
+func (c *Cache) demote(element *list.Element, ghosts *list.List) {
+	element.Value.(*entry).value = nil
+	c.moveToFront(element, ghosts)
+}
+
+// moveToFront moves an element to the front of the target list
+func (c *Cache) moveToFront(element *list.Element, target *list.List) {
+	e := element.Value.(*entry)
+	if e.list == target {
+		target.MoveToFront(element)
+		return
+	}
+	e.list.Remove(element)
+	e.list = target
+	c.cache[e.key] = target.PushFront(e)
+}
+
+// removeBack drops the least recent element of a list
+func (c *Cache) removeBack(l *list.List) {
+	if element := l.Back(); element != nil {
+		c.removeElement(element)
+	}
+}
+
+// removeElement removes a specific element
+func (c *Cache) removeElement(element *list.Element) {
+	e := element.Value.(*entry)
+	e.list.Remove(element)
+	delete(c.cache, e.key)
+}
+
+// resident looks up a key in T1 or T2
+func (c *Cache) resident(key any) (*list.Element, bool) {
+	element, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if l := element.Value.(*entry).list; l != c.t1 && l != c.t2 {
+		return nil, false
+	}
+	return element, true
+}
+
+// Remove removes a key from the cache and forgets its history
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	_, resident := c.resident(key)
+	c.removeElement(element)
+	return resident
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.p = 0
+	c.t1 = list.New()
+	c.t2 = list.New()
+	c.b1 = list.New()
+	c.b2 = list.New()
+	c.cache = make(map[any]*list.Element)
+}
+
+// Keys returns all keys in the cache, the frequency list T2 first and then the
+// recency list T1, each most recently used first
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, c.t1.Len()+c.t2.Len())
+	for _, l := range []*list.List{c.t2, c.t1} {
+		for element := l.Front(); element != nil; element = element.Next() {
+			keys = append(keys, element.Value.(*entry).key)
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.resident(key)
+	return ok
+}
+
+// Peek looks up a value without updating the access order
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.resident(key); ok {
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,330 @@
+package arc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+)
+
+func TestARCCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit
+	cache.Put("key3", "value3") // key1 was used twice, key2 only once
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestARCCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestARCCacheLists(t *testing.T) {
+	cache := New(4)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Get(1)
+
+	// Keys seen once stay in T1, keys seen twice move to T2
+	if cache.t1.Len() != 2 || cache.t2.Len() != 1 {
+		t.Errorf("Expected |T1|=2 and |T2|=1, got %d and %d", cache.t1.Len(), cache.t2.Len())
+	}
+
+	keys := cache.Keys()
+	expected := []any{1, 3, 2}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+}
+
+func TestARCCacheGhostHit(t *testing.T) {
+	cache := New(2)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Get(2)
+	cache.Put(3, 3) // 1 leaves T1 for the ghost list B1
+
+	if cache.Contains(1) {
+		t.Fatal("key 1 should have been evicted")
+	}
+	if cache.b1.Len() != 1 {
+		t.Fatalf("Expected key 1 in B1, got |B1|=%d", cache.b1.Len())
+	}
+
+	// Seeing 1 again is a ghost hit: T1 was too small
+	cache.Put(1, 1)
+
+	if cache.p != 1 {
+		t.Errorf("Expected target size p to grow to 1, got %d", cache.p)
+	}
+	if element, ok := cache.resident(1); !ok || element.Value.(*entry).list != cache.t2 {
+		t.Error("A ghost hit should bring the key back into T2")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+}
+
+func TestARCCacheAdaptsToFrequency(t *testing.T) {
+	cache := New(2)
+
+	cache.Put(1, 1)
+	cache.Get(1)
+	cache.Put(2, 2)
+	cache.Get(2)
+	cache.Put(3, 3) // T2 is full, 1 goes to B2
+	cache.Put(4, 4)
+
+	p := cache.p
+	cache.Put(1, 1) // ghost hit in B2 shrinks T1's target
+
+	if cache.p > p {
+		t.Errorf("A B2 ghost hit should not grow p, was %d now %d", p, cache.p)
+	}
+	if !cache.Contains(1) {
+		t.Error("key 1 should be back in the cache")
+	}
+}
+
+func TestARCCacheScanResistance(t *testing.T) {
+	cache := New(10)
+
+	// A working set used repeatedly
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 5; i++ {
+			if _, ok := cache.Get(i); !ok {
+				cache.Put(i, i)
+			}
+		}
+	}
+
+	// A one-off scan only churns T1
+	for i := 100; i < 200; i++ {
+		cache.Put(i, i)
+	}
+
+	for i := 0; i < 5; i++ {
+		if !cache.Contains(i) {
+			t.Errorf("Frequent key %d should survive the scan", i)
+		}
+	}
+}
+
+func TestARCCacheGhostBounds(t *testing.T) {
+	cache := New(8)
+
+	for i := 0; i < 1000; i++ {
+		key := (i * 7) % 40
+		if _, ok := cache.Get(key); !ok {
+			cache.Put(key, i)
+		}
+		t1, t2, b1, b2 := cache.t1.Len(), cache.t2.Len(), cache.b1.Len(), cache.b2.Len()
+		if t1+t2 > 8 || t1+b1 > 8 || t1+t2+b1+b2 > 16 {
+			t.Fatalf("List sizes out of bounds: T1=%d T2=%d B1=%d B2=%d", t1, t2, b1, b2)
+		}
+		if len(cache.cache) != t1+t2+b1+b2 {
+			t.Fatalf("Map holds %d keys, lists hold %d", len(cache.cache), t1+t2+b1+b2)
+		}
+	}
+}
+
+func TestARCCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+}
+
+func TestARCCachePeek(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	// Peek should not promote the key to T2
+	if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+		t.Errorf("Peek should return value1, got %v", value)
+	}
+	if cache.t2.Len() != 0 {
+		t.Error("Peek should not move the key to T2")
+	}
+
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+}
+
+func TestARCCacheClear(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+	if cache.b1.Len() != 0 || cache.p != 0 {
+		t.Error("Clear should also reset the ghost lists and p")
+	}
+}
+
+func TestARCCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestARCCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkARCCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkARCCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkARCCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestARCCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
@@ -0,0 +1,24 @@
+// Package cache defines the interface shared by the cache implementations in this repository
+package cache
+
+// Cache key-value cache with bounded capacity, safe for concurrent use
+type Cache interface {
+	// Get retrieves a value and records the access
+	Get(key any) (any, bool)
+	// Put adds or updates a key-value pair, evicting entries if the cache is full
+	Put(key, value any)
+	// Remove removes a key and reports whether it was present
+	Remove(key any) bool
+	// Peek retrieves a value without recording the access
+	Peek(key any) (any, bool)
+	// Contains checks if the cache contains a key without recording the access
+	Contains(key any) bool
+	// Keys returns all keys in the cache, in an order defined by the implementation
+	Keys() []any
+	// Len returns the number of elements in the cache
+	Len() int
+	// Cap returns the capacity of the cache
+	Cap() int
+	// Clear removes all elements from the cache
+	Clear()
+}
@@ -0,0 +1,223 @@
+// Package cachetest implements a conformance suite for cache.Cache implementations
+package cachetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// roomy capacity for checks that must not evict, large enough that keys
+// spread over shards or segments still fit
+const roomy = 64
+
+// Factory creates an empty cache with the given capacity
+type Factory func(capacity int) cache.Cache
+
+// Run checks the behavior every cache.Cache implementation must share.
+// It makes no assumption about which entry is evicted when the cache is full.
+func Run(t *testing.T, newCache Factory) {
+	t.Run("PutGet", func(t *testing.T) { testPutGet(t, newCache) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newCache) })
+	t.Run("Remove", func(t *testing.T) { testRemove(t, newCache) })
+	t.Run("PeekContains", func(t *testing.T) { testPeekContains(t, newCache) })
+	t.Run("Keys", func(t *testing.T) { testKeys(t, newCache) })
+	t.Run("Clear", func(t *testing.T) { testClear(t, newCache) })
+	t.Run("Capacity", func(t *testing.T) { testCapacity(t, newCache) })
+	t.Run("Concurrency", func(t *testing.T) { testConcurrency(t, newCache) })
+}
+
+func testPutGet(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	for i := 0; i < 4; i++ {
+		c.Put(i, fmt.Sprint("value", i))
+	}
+
+	for i := 0; i < 4; i++ {
+		if value, ok := c.Get(i); !ok || value != fmt.Sprint("value", i) {
+			t.Errorf("Get(%d) = %v, %v, want value%d, true", i, value, ok, i)
+		}
+	}
+
+	if value, ok := c.Get("missing"); ok || value != nil {
+		t.Errorf("Get(missing) = %v, %v, want nil, false", value, ok)
+	}
+
+	if c.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", c.Len())
+	}
+}
+
+func testUpdate(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	c.Put("key", "value")
+	c.Put("key", "updated")
+
+	if value, ok := c.Get("key"); !ok || value != "updated" {
+		t.Errorf("Get(key) = %v, %v, want updated, true", value, ok)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d after updating a key, want 1", c.Len())
+	}
+}
+
+func testRemove(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+
+	if !c.Remove("a") {
+		t.Error("Remove(a) = false, want true")
+	}
+	if c.Remove("a") {
+		t.Error("Remove(a) = true for a removed key, want false")
+	}
+	if c.Remove("missing") {
+		t.Error("Remove(missing) = true, want false")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) found a removed key")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+}
+
+func testPeekContains(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	c.Put("a", 1)
+
+	if value, ok := c.Peek("a"); !ok || value != 1 {
+		t.Errorf("Peek(a) = %v, %v, want 1, true", value, ok)
+	}
+	if value, ok := c.Peek("missing"); ok || value != nil {
+		t.Errorf("Peek(missing) = %v, %v, want nil, false", value, ok)
+	}
+	if !c.Contains("a") {
+		t.Error("Contains(a) = false, want true")
+	}
+	if c.Contains("missing") {
+		t.Error("Contains(missing) = true, want false")
+	}
+}
+
+func testKeys(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+	c.Get(2)
+	c.Put(3, 30)
+
+	keys := c.Keys()
+	if len(keys) != c.Len() {
+		t.Fatalf("len(Keys()) = %d, want Len() = %d", len(keys), c.Len())
+	}
+
+	seen := make(map[any]bool)
+	for _, key := range keys {
+		if seen[key] {
+			t.Errorf("Keys() returned %v twice", key)
+		}
+		seen[key] = true
+	}
+	for i := 0; i < 5; i++ {
+		if !seen[i] {
+			t.Errorf("Keys() is missing %d", i)
+		}
+	}
+}
+
+func testClear(t *testing.T, newCache Factory) {
+	c := newCache(roomy)
+
+	for i := 0; i < 5; i++ {
+		c.Put(i, i)
+	}
+	c.Clear()
+
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d after Clear, want 0", c.Len())
+	}
+	if len(c.Keys()) != 0 {
+		t.Errorf("Keys() = %v after Clear, want none", c.Keys())
+	}
+	if _, ok := c.Get(0); ok {
+		t.Error("Get(0) found a key after Clear")
+	}
+
+	// The cache stays usable after Clear
+	c.Put("a", 1)
+	if value, ok := c.Get("a"); !ok || value != 1 {
+		t.Errorf("Get(a) = %v, %v after Clear, want 1, true", value, ok)
+	}
+}
+
+func testCapacity(t *testing.T, newCache Factory) {
+	c := newCache(8)
+	capacity := c.Cap()
+
+	for i := 0; i < 10*capacity; i++ {
+		c.Put(i, i)
+		if c.Len() > capacity {
+			t.Fatalf("Len() = %d exceeds Cap() = %d", c.Len(), capacity)
+		}
+		// The most recent entry is always readable
+		if value, ok := c.Peek(i); !ok || value != i {
+			t.Fatalf("Peek(%d) = %v, %v right after Put, want %d, true", i, value, ok, i)
+		}
+	}
+
+	if c.Cap() != capacity {
+		t.Errorf("Cap() = %d, changed from %d", c.Cap(), capacity)
+	}
+}
+
+func testConcurrency(t *testing.T, newCache Factory) {
+	c := newCache(64)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				key := (id*200 + j) % 128
+				c.Put(key, key)
+				if value, ok := c.Get(key); ok && value != key {
+					t.Errorf("Get(%d) = %v", key, value)
+				}
+				c.Peek(key)
+				c.Contains(key)
+				if j%10 == 0 {
+					c.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Len()
+			c.Keys()
+			if i%25 == 0 {
+				c.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if c.Len() > c.Cap() {
+		t.Errorf("Len() = %d exceeds Cap() = %d", c.Len(), c.Cap())
+	}
+}
@@ -0,0 +1,77 @@
+package clock
+
+import (
+	"testing"
+)
+
+// Concurrent benchmarks
+
+func BenchmarkClockCacheConcurrentWrite(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkClockCacheConcurrentReadWrite(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 500; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%3 == 0 {
+				cache.Put(i, i)
+			} else {
+				cache.Get(i % 500)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkClockProCacheConcurrentWrite(b *testing.B) {
+	cache := NewPro(1000)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkClockProCacheConcurrentReadWrite(b *testing.B) {
+	cache := NewPro(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 500; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%3 == 0 {
+				cache.Put(i, i)
+			} else {
+				cache.Get(i % 500)
+			}
+			i++
+		}
+	})
+}
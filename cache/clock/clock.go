@@ -0,0 +1,181 @@
+package clock
+
+import (
+	"container/ring"
+	"sync"
+	"sync/atomic"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Cache CLOCK cache structure.
+//
+// Entries sit on a circular ring with a hand pointing at the next candidate for
+// eviction. A hit only sets the entry's reference bit, so Get runs under a read
+// lock. On eviction the hand clears reference bits until it finds an entry
+// without one, giving every referenced entry a second chance.
+type Cache struct {
+	capacity int
+	cache    map[any]*ring.Ring
+	hand     *ring.Ring // next candidate for eviction, nil when empty
+	mutex    sync.RWMutex
+}
+
+// entry cache entry
+type entry struct {
+	key        any
+	value      any
+	referenced atomic.Bool
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new CLOCK cache
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		cache:    make(map[any]*ring.Ring),
+	}
+}
+
+// Get retrieves a value from the cache and sets its reference bit
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if r, ok := c.cache[key]; ok {
+		e := r.Value.(*entry)
+		// Skip the store when the bit is already set to keep the cache line shared
+		if !e.referenced.Load() {
+			e.referenced.Store(true)
+		}
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if r, ok := c.cache[key]; ok {
+		e := r.Value.(*entry)
+		e.value = value
+		e.referenced.Store(true)
+		return
+	}
+
+	// If the cache is full, sweep the hand to find a victim
+	if len(c.cache) >= c.capacity {
+		c.evict()
+	}
+
+	r := &ring.Ring{Value: &entry{key: key, value: value}}
+	c.cache[key] = r
+	if c.hand == nil {
+		c.hand = r
+		return
+	}
+	// Just behind the hand, so a new entry is examined last
+	c.hand.Prev().Link(r)
+}
+
+// evict removes the first entry the hand finds without a reference bit
+func (c *Cache) evict() {
+	for c.hand != nil {
+		e := c.hand.Value.(*entry)
+		if e.referenced.Swap(false) {
+			c.hand = c.hand.Next()
+			continue
+		}
+		c.removeRing(c.hand)
+		return
+	}
+}
+
+// removeRing unlinks an entry from the ring, moving the hand past it
+func (c *Cache) removeRing(r *ring.Ring) {
+	delete(c.cache, r.Value.(*entry).key)
+	if r.Next() == r {
+		c.hand = nil
+		return
+	}
+	if c.hand == r {
+		c.hand = r.Next()
+	}
+	r.Prev().Unlink(1)
+}
+
+// Remove removes a key from the cache
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if r, ok := c.cache[key]; ok {
+		c.removeRing(r)
+		return true
+	}
+	return false
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.cache)
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cache = make(map[any]*ring.Ring)
+	c.hand = nil
+}
+
+// Keys returns all keys in the cache in the reverse of the order the hand reaches
+// them, so the next candidate for eviction comes last
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, len(c.cache))
+	if c.hand == nil {
+		return keys
+	}
+	for r := c.hand.Prev(); ; r = r.Prev() {
+		keys = append(keys, r.Value.(*entry).key)
+		if r == c.hand {
+			break
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without setting its reference bit
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if r, ok := c.cache[key]; ok {
+		return r.Value.(*entry).value, true
+	}
+	return nil, false
+}
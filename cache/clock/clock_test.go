@@ -0,0 +1,276 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+)
+
+func TestClockCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, key1 gets a second chance
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestClockCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestClockCacheSecondChance(t *testing.T) {
+	cache := New(3)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+
+	// Every key is referenced, the hand clears them all and comes back to 1
+	cache.Get(1)
+	cache.Get(2)
+	cache.Get(3)
+	cache.Put(4, 4)
+
+	if cache.Contains(1) {
+		t.Error("key 1 should have been evicted after a full sweep")
+	}
+
+	// The hand now points at 2, whose bit was cleared
+	cache.Get(3)
+	cache.Put(5, 5)
+
+	if cache.Contains(2) {
+		t.Error("key 2 should have been evicted")
+	}
+	for _, key := range []int{3, 4, 5} {
+		if !cache.Contains(key) {
+			t.Errorf("key %d should still be in cache", key)
+		}
+	}
+}
+
+func TestClockCacheKeys(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	keys := cache.Keys()
+	expected := []any{"c", "b", "a"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %d keys, got %d", len(expected), len(keys))
+	}
+
+	// The next candidate for eviction comes last
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+}
+
+func TestClockCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key, including the one under the hand
+	if !cache.Remove("a") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("key a should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+
+	// Removing the last key leaves an empty ring
+	cache.Remove("b")
+	cache.Remove("c")
+	if cache.hand != nil || len(cache.Keys()) != 0 {
+		t.Error("Expected an empty ring")
+	}
+	cache.Put("d", 4)
+	if value, ok := cache.Get("d"); !ok || value != 4 {
+		t.Errorf("Expected 4, got %v", value)
+	}
+}
+
+func TestClockCachePeek(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	// Peek should not set the reference bit
+	if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+		t.Errorf("Peek should return value1, got %v", value)
+	}
+
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+}
+
+func TestClockCacheClear(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Cache should be empty after clear")
+	}
+}
+
+func TestClockCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestClockCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkClockCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkClockCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkClockCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestClockCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
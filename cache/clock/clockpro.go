@@ -0,0 +1,300 @@
+package clock
+
+import (
+	"container/ring"
+	"sync"
+	"sync/atomic"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// pageType the state of an entry on the CLOCK-Pro ring
+type pageType int
+
+const (
+	pageCold pageType = iota // resident, not yet proven hot
+	pageHot                  // resident, reused within its test period
+	pageTest                 // non-resident, a cold entry still in its test period
+)
+
+// ProCache CLOCK-Pro cache structure.
+//
+// Like CLOCK a hit only sets a reference bit, but entries are classified as hot or
+// cold by their reuse distance. A cold entry evicted while in its test period stays
+// on the ring as a non-resident test entry; if it is put again before the test
+// period ends it comes back hot. Three hands sweep the ring: the cold hand evicts
+// cold entries, the hot hand demotes hot entries without a reference bit and the
+// test hand ends test periods. The target number of cold entries adapts like the
+// recency share of ARC.
+type ProCache struct {
+	capacity  int
+	coldCap   int // adaptive target for resident cold entries
+	cache     map[any]*ring.Ring
+	handHot   *ring.Ring
+	handCold  *ring.Ring
+	handTest  *ring.Ring
+	countHot  int
+	countCold int
+	countTest int
+	mutex     sync.RWMutex
+}
+
+// proEntry CLOCK-Pro cache entry, test entries keep the key only
+type proEntry struct {
+	key        any
+	value      any
+	page       pageType
+	referenced atomic.Bool
+}
+
+var _ cache.Cache = (*ProCache)(nil)
+
+// NewPro creates a new CLOCK-Pro cache. A capacity below 1 is treated as 1, the
+// cache then keeps only the most recent entry like the other caches.
+func NewPro(capacity int) *ProCache {
+	capacity = max(capacity, 1)
+	return &ProCache{
+		capacity: capacity,
+		coldCap:  capacity,
+		cache:    make(map[any]*ring.Ring),
+	}
+}
+
+// Get retrieves a value from the cache and sets its reference bit
+func (c *ProCache) Get(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if r, ok := c.resident(key); ok {
+		e := r.Value.(*proEntry)
+		// Skip the store when the bit is already set to keep the cache line shared
+		if !e.referenced.Load() {
+			e.referenced.Store(true)
+		}
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache
+func (c *ProCache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	r, ok := c.cache[key]
+	if !ok {
+		c.add(&proEntry{key: key, value: value, page: pageCold})
+		c.countCold++
+		return
+	}
+
+	e := r.Value.(*proEntry)
+	if e.page != pageTest {
+		e.value = value
+		e.referenced.Store(true)
+		return
+	}
+
+	// Reused within its test period: cold entries deserve more room
+	if c.coldCap < c.capacity {
+		c.coldCap++
+	}
+	c.unlink(r)
+	c.countTest--
+	e.value = value
+	e.page = pageHot
+	e.referenced.Store(false)
+	c.add(e)
+	c.countHot++
+}
+
+// add makes room and links a new entry just behind the hot hand
+func (c *ProCache) add(e *proEntry) {
+	for c.countHot+c.countCold >= c.capacity {
+		if c.countCold == 0 {
+			c.runHandHot()
+			continue
+		}
+		c.runHandCold()
+	}
+
+	r := &ring.Ring{Value: e}
+	c.cache[e.key] = r
+	if c.handHot == nil {
+		c.handHot, c.handCold, c.handTest = r, r, r
+		return
+	}
+	c.handHot.Prev().Link(r)
+}
+
+// runHandCold moves the cold hand by one entry. A referenced cold entry is promoted
+// to hot, an unreferenced one is evicted and kept as a test entry.
+func (c *ProCache) runHandCold() {
+	e := c.handCold.Value.(*proEntry)
+	c.handCold = c.handCold.Next()
+	if e.page != pageCold {
+		return
+	}
+
+	if e.referenced.Swap(false) {
+		e.page = pageHot
+		c.countCold--
+		c.countHot++
+	} else {
+		e.page = pageTest
+		e.value = nil
+		c.countCold--
+		c.countTest++
+		for c.countTest > c.capacity {
+			c.runHandTest()
+		}
+	}
+
+	for c.countHot > 0 && c.countHot > c.capacity-c.coldCap {
+		c.runHandHot()
+	}
+}
+
+// runHandHot moves the hot hand by one entry, demoting a hot entry to cold unless
+// it was referenced since the hand last passed
+func (c *ProCache) runHandHot() {
+	e := c.handHot.Value.(*proEntry)
+	c.handHot = c.handHot.Next()
+	if e.page == pageHot && !e.referenced.Swap(false) {
+		e.page = pageCold
+		c.countHot--
+		c.countCold++
+	}
+}
+
+// runHandTest moves the test hand by one entry, ending the test period of a test entry
+func (c *ProCache) runHandTest() {
+	r := c.handTest
+	if r.Value.(*proEntry).page != pageTest {
+		c.handTest = r.Next()
+		return
+	}
+
+	// Not reused in time: cold entries deserve less room
+	c.unlink(r)
+	c.countTest--
+	if c.coldCap > 1 {
+		c.coldCap--
+	}
+}
+
+// unlink removes an entry from the ring and the map, moving hands that point at it
+// on to the next entry
+func (c *ProCache) unlink(r *ring.Ring) {
+	delete(c.cache, r.Value.(*proEntry).key)
+	if r.Next() == r {
+		c.handHot, c.handCold, c.handTest = nil, nil, nil
+		return
+	}
+	next := r.Next()
+	if c.handHot == r {
+		c.handHot = next
+	}
+	if c.handCold == r {
+		c.handCold = next
+	}
+	if c.handTest == r {
+		c.handTest = next
+	}
+	r.Prev().Unlink(1)
+}
+
+// resident looks up a hot or cold entry
+func (c *ProCache) resident(key any) (*ring.Ring, bool) {
+	r, ok := c.cache[key]
+	if !ok || r.Value.(*proEntry).page == pageTest {
+		return nil, false
+	}
+	return r, true
+}
+
+// Remove removes a key from the cache and forgets its history
+func (c *ProCache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	r, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	e := r.Value.(*proEntry)
+	switch e.page {
+	case pageHot:
+		c.countHot--
+	case pageCold:
+		c.countCold--
+	case pageTest:
+		c.countTest--
+	}
+	c.unlink(r)
+	return e.page != pageTest
+}
+
+// Len returns the number of elements in the cache
+func (c *ProCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.countHot + c.countCold
+}
+
+// Cap returns the capacity of the cache
+func (c *ProCache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *ProCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.coldCap = c.capacity
+	c.cache = make(map[any]*ring.Ring)
+	c.handHot, c.handCold, c.handTest = nil, nil, nil
+	c.countHot, c.countCold, c.countTest = 0, 0, 0
+}
+
+// Keys returns all keys in the cache, most recently added first
+func (c *ProCache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, c.countHot+c.countCold)
+	if c.handHot == nil {
+		return keys
+	}
+	for r := c.handHot.Prev(); ; r = r.Prev() {
+		if e := r.Value.(*proEntry); e.page != pageTest {
+			keys = append(keys, e.key)
+		}
+		if r == c.handHot {
+			break
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *ProCache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.resident(key)
+	return ok
+}
+
+// Peek looks up a value without setting its reference bit
+func (c *ProCache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if r, ok := c.resident(key); ok {
+		return r.Value.(*proEntry).value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,355 @@
+package clock
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+// checkProInvariants verifies the counters agree with the ring and the map
+func checkProInvariants(t *testing.T, c *ProCache) {
+	t.Helper()
+
+	hot, cold, test := 0, 0, 0
+	if c.handHot != nil {
+		for r := c.handHot.Next(); ; r = r.Next() {
+			switch r.Value.(*proEntry).page {
+			case pageHot:
+				hot++
+			case pageCold:
+				cold++
+			case pageTest:
+				test++
+			}
+			if r == c.handHot {
+				break
+			}
+		}
+	}
+	if hot != c.countHot || cold != c.countCold || test != c.countTest {
+		t.Fatalf("Counters hot=%d cold=%d test=%d, ring holds %d, %d, %d",
+			c.countHot, c.countCold, c.countTest, hot, cold, test)
+	}
+	if len(c.cache) != hot+cold+test {
+		t.Fatalf("Map holds %d keys, ring holds %d", len(c.cache), hot+cold+test)
+	}
+	if hot+cold > c.capacity || test > c.capacity {
+		t.Fatalf("Too many entries: %d resident and %d test for capacity %d", hot+cold, test, c.capacity)
+	}
+}
+
+func TestClockProCache(t *testing.T) {
+	cache := NewPro(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, key1 was referenced and is promoted instead
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+	checkProInvariants(t, cache)
+}
+
+func TestClockProCacheUpdate(t *testing.T) {
+	cache := NewPro(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestClockProCacheTestPeriod(t *testing.T) {
+	cache := NewPro(2)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3) // 1 is evicted but stays on the ring as a test entry
+
+	if cache.Contains(1) {
+		t.Fatal("key 1 should have been evicted")
+	}
+	if cache.countTest != 1 {
+		t.Fatalf("Expected one test entry, got %d", cache.countTest)
+	}
+
+	// Put again within its test period: 1 comes back hot
+	cache.Put(1, "again")
+
+	r, ok := cache.resident(1)
+	if !ok || r.Value.(*proEntry).page != pageHot {
+		t.Fatal("key 1 should be back as a hot entry")
+	}
+	if value, _ := cache.Get(1); value != "again" {
+		t.Errorf("Expected again, got %v", value)
+	}
+	checkProInvariants(t, cache)
+}
+
+func TestClockProCacheScanResistance(t *testing.T) {
+	pro := NewPro(100)
+	lruCache := lru.New(100)
+
+	hits := make(map[cache.Cache]int)
+	for _, c := range []cache.Cache{pro, lruCache} {
+		// A popular working set is used again after every scan of 150 cold keys, which
+		// is enough to flush an LRU cache of the same size
+		for i := 0; i < 4000; i++ {
+			if i%150 == 0 {
+				for key := 0; key < 20; key++ {
+					if _, ok := c.Get(key); ok {
+						hits[c]++
+					} else {
+						c.Put(key, key)
+					}
+				}
+			}
+			if _, ok := c.Get(1000 + i); !ok {
+				c.Put(1000+i, i)
+			}
+		}
+	}
+
+	t.Logf("working set hits: CLOCK-Pro %d, LRU %d", hits[pro], hits[lruCache])
+	if hits[pro] <= hits[lruCache] {
+		t.Errorf("Expected CLOCK-Pro to keep more of the working set than LRU, %d vs %d hits",
+			hits[pro], hits[lruCache])
+	}
+	checkProInvariants(t, pro)
+}
+
+func TestClockProCacheRandomOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, capacity := range []int{1, 2, 3, 8, 50} {
+		cache := NewPro(capacity)
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(4 * capacity)
+			switch op := r.Intn(10); {
+			case op < 5:
+				cache.Put(key, key)
+			case op < 9:
+				if value, ok := cache.Get(key); ok && value != key {
+					t.Fatalf("Get(%d) = %v", key, value)
+				}
+			default:
+				cache.Remove(key)
+			}
+			checkProInvariants(t, cache)
+		}
+	}
+}
+
+func TestClockProCacheRemove(t *testing.T) {
+	cache := NewPro(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+
+	// A test entry is forgotten but was not in the cache
+	cache.Put("d", 4)
+	cache.Put("e", 5)
+	if cache.countTest == 0 {
+		t.Fatal("Expected a test entry")
+	}
+	for key := range cache.cache {
+		if _, ok := cache.resident(key); !ok && cache.Remove(key) {
+			t.Error("Remove should return false for a test entry")
+		}
+	}
+	checkProInvariants(t, cache)
+}
+
+func TestClockProCachePeek(t *testing.T) {
+	cache := NewPro(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	// Peek should not set the reference bit
+	if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+		t.Errorf("Peek should return value1, got %v", value)
+	}
+
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+}
+
+func TestClockProCacheClear(t *testing.T) {
+	cache := NewPro(3)
+
+	for i := 0; i < 6; i++ {
+		cache.Put(i, i)
+	}
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+	checkProInvariants(t, cache)
+}
+
+func TestClockProCacheCapacity(t *testing.T) {
+	cache := NewPro(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestClockProCacheZeroCapacity(t *testing.T) {
+	for _, capacity := range []int{0, -1} {
+		cache := NewPro(capacity)
+
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+
+		if cache.Len() != 1 || !cache.Contains("b") {
+			t.Errorf("NewPro(%d): expected only the latest key, got %v", capacity, cache.Keys())
+		}
+		if cache.Cap() != 1 {
+			t.Errorf("NewPro(%d): expected capacity 1, got %d", capacity, cache.Cap())
+		}
+		checkProInvariants(t, cache)
+	}
+}
+
+func TestClockProCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return NewPro(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkClockProCachePut(b *testing.B) {
+	cache := NewPro(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkClockProCacheGet(b *testing.B) {
+	cache := NewPro(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkClockProCacheMixed(b *testing.B) {
+	cache := NewPro(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%150 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestClockProCacheConcurrency(t *testing.T) {
+	cache := NewPro(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%150 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
@@ -0,0 +1,206 @@
+// Command cachesim replays an access trace against every cache implementation
+// in this repository at several capacities and reports the hit ratios.
+//
+// Usage:
+//
+//	cachesim -trace access.log [-format plain|arc|lirs|csv] [-sizes 100,1000] [-policies lru,arc] [-csv]
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/arc"
+	"github.com/loveRyujin/go-algorithm/cache/clock"
+	"github.com/loveRyujin/go-algorithm/cache/lfu"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+	"github.com/loveRyujin/go-algorithm/cache/policy"
+	"github.com/loveRyujin/go-algorithm/cache/sieve"
+	"github.com/loveRyujin/go-algorithm/cache/tinylfu"
+	"github.com/loveRyujin/go-algorithm/cache/trace"
+	"github.com/loveRyujin/go-algorithm/cache/twoq"
+)
+
+// implementation a cache under test
+type implementation struct {
+	name     string
+	newCache func(capacity int) cache.Cache
+}
+
+var implementations = []implementation{
+	{"lru", func(capacity int) cache.Cache { return lru.New(capacity) }},
+	{"lru-syncmap", func(capacity int) cache.Cache { return lru.NewSyncMap(capacity) }},
+	{"lru-sharded", func(capacity int) cache.Cache { return lru.NewSharded(capacity, 4) }},
+	{"slru", func(capacity int) cache.Cache { return lru.NewSLRU(capacity) }},
+	{"lfu", func(capacity int) cache.Cache { return lfu.New(capacity) }},
+	{"arc", func(capacity int) cache.Cache { return arc.New(capacity) }},
+	{"2q", func(capacity int) cache.Cache { return twoq.New(capacity) }},
+	{"tinylfu", func(capacity int) cache.Cache { return tinylfu.New(capacity) }},
+	{"clock", func(capacity int) cache.Cache { return clock.New(capacity) }},
+	{"clock-pro", func(capacity int) cache.Cache { return clock.NewPro(capacity) }},
+	{"sieve", func(capacity int) cache.Cache { return sieve.New(capacity) }},
+	{"fifo", func(capacity int) cache.Cache { return policy.New(capacity, policy.NewFIFO()) }},
+	{"lifo", func(capacity int) cache.Cache { return policy.New(capacity, policy.NewLIFO()) }},
+	{"mru", func(capacity int) cache.Cache { return policy.New(capacity, policy.NewMRU()) }},
+	{"random", func(capacity int) cache.Cache { return policy.New(capacity, policy.NewRandom(1)) }},
+}
+
+// defaultFractions capacities used without -sizes, as fractions of the distinct keys
+var defaultFractions = []float64{0.01, 0.05, 0.1, 0.25, 0.5}
+
+// result hit ratio of one implementation at one capacity
+type result struct {
+	name     string
+	capacity int
+	trace.Result
+}
+
+func main() {
+	tracePath := flag.String("trace", "-", "trace file to replay, - for standard input")
+	formatName := flag.String("format", "plain", "trace format: plain, arc, lirs or csv")
+	sizesFlag := flag.String("sizes", "", "comma separated cache capacities (default 1%, 5%, 10%, 25% and 50% of the distinct keys)")
+	policiesFlag := flag.String("policies", "all", "comma separated implementations to replay, or all")
+	csvOutput := flag.Bool("csv", false, "write CSV instead of a table")
+	flag.Parse()
+
+	if err := run(*tracePath, *formatName, *sizesFlag, *policiesFlag, *csvOutput); err != nil {
+		fmt.Fprintln(os.Stderr, "cachesim:", err)
+		os.Exit(1)
+	}
+}
+
+func run(tracePath, formatName, sizesFlag, policiesFlag string, csvOutput bool) error {
+	format, err := trace.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+	selected, err := selectImplementations(policiesFlag)
+	if err != nil {
+		return err
+	}
+
+	accesses, err := readTrace(tracePath, format)
+	if err != nil {
+		return err
+	}
+	if len(accesses) == 0 {
+		return fmt.Errorf("trace %s is empty", tracePath)
+	}
+
+	sizes, err := parseSizes(sizesFlag, accesses)
+	if err != nil {
+		return err
+	}
+
+	var results []result
+	for _, capacity := range sizes {
+		for _, impl := range selected {
+			r := trace.Replay(impl.newCache(capacity), accesses)
+			results = append(results, result{name: impl.name, capacity: capacity, Result: r})
+		}
+	}
+
+	if csvOutput {
+		return writeCSV(os.Stdout, results)
+	}
+	return writeTable(os.Stdout, selected, sizes, results, len(accesses))
+}
+
+func readTrace(path string, format trace.Format) ([]trace.Access, error) {
+	if path == "-" {
+		return trace.Read(os.Stdin, format)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return trace.Read(f, format)
+}
+
+func selectImplementations(names string) ([]implementation, error) {
+	if names == "all" {
+		return implementations, nil
+	}
+	var selected []implementation
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		i := slices.IndexFunc(implementations, func(impl implementation) bool { return impl.name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("unknown implementation %q", name)
+		}
+		selected = append(selected, implementations[i])
+	}
+	return selected, nil
+}
+
+// parseSizes parses -sizes, or derives capacities from the number of distinct keys
+func parseSizes(sizesFlag string, accesses []trace.Access) ([]int, error) {
+	var sizes []int
+	if sizesFlag != "" {
+		for _, field := range strings.Split(sizesFlag, ",") {
+			size, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil || size < 1 {
+				return nil, fmt.Errorf("invalid cache size %q", field)
+			}
+			sizes = append(sizes, size)
+		}
+	} else {
+		distinct := make(map[string]struct{})
+		for _, access := range accesses {
+			distinct[access.Key] = struct{}{}
+		}
+		for _, fraction := range defaultFractions {
+			sizes = append(sizes, max(1, int(float64(len(distinct))*fraction)))
+		}
+	}
+	slices.Sort(sizes)
+	return slices.Compact(sizes), nil
+}
+
+// writeTable prints one row per capacity and one column per implementation
+func writeTable(w io.Writer, selected []implementation, sizes []int, results []result, requests int) error {
+	fmt.Fprintf(w, "Hit ratio over %d requests\n\n", requests)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "capacity\t")
+	for _, impl := range selected {
+		fmt.Fprintf(tw, "%s\t", impl.name)
+	}
+	fmt.Fprintln(tw)
+
+	for i, capacity := range sizes {
+		fmt.Fprintf(tw, "%d\t", capacity)
+		for _, r := range results[i*len(selected) : (i+1)*len(selected)] {
+			fmt.Fprintf(tw, "%.2f%%\t", r.HitRatio()*100)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// writeCSV writes one record per implementation and capacity
+func writeCSV(w io.Writer, results []result) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"policy", "capacity", "requests", "hits", "hit_ratio", "byte_hit_ratio"})
+	for _, r := range results {
+		cw.Write([]string{
+			r.name,
+			strconv.Itoa(r.capacity),
+			strconv.FormatUint(r.Requests, 10),
+			strconv.FormatUint(r.Hits, 10),
+			strconv.FormatFloat(r.HitRatio(), 'f', 6, 64),
+			strconv.FormatFloat(r.ByteHitRatio(), 'f', 6, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
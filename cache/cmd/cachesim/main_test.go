@@ -0,0 +1,154 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache/trace"
+)
+
+func TestParseSizes(t *testing.T) {
+	sizes, err := parseSizes(" 100, 10,100,1 ", nil)
+	if err != nil {
+		t.Fatalf("parseSizes failed: %v", err)
+	}
+	// Sorted and without duplicates
+	if !slices.Equal(sizes, []int{1, 10, 100}) {
+		t.Errorf("Expected [1 10 100], got %v", sizes)
+	}
+
+	for _, invalid := range []string{"0", "-5", "ten", "10,", "10,,20"} {
+		if _, err := parseSizes(invalid, nil); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestParseSizesDefault(t *testing.T) {
+	// 1000 distinct keys, each accessed twice
+	var accesses []trace.Access
+	for i := 0; i < 2000; i++ {
+		accesses = append(accesses, trace.Access{Key: strconv.Itoa(i % 1000), Size: 1})
+	}
+
+	sizes, err := parseSizes("", accesses)
+	if err != nil {
+		t.Fatalf("parseSizes failed: %v", err)
+	}
+	if !slices.Equal(sizes, []int{10, 50, 100, 250, 500}) {
+		t.Errorf("Expected fractions of the distinct keys, got %v", sizes)
+	}
+
+	// Tiny traces still get a capacity of at least 1, without duplicates
+	sizes, _ = parseSizes("", []trace.Access{{Key: "a"}, {Key: "b"}})
+	if !slices.Equal(sizes, []int{1}) {
+		t.Errorf("Expected [1], got %v", sizes)
+	}
+}
+
+func TestSelectImplementations(t *testing.T) {
+	all, err := selectImplementations("all")
+	if err != nil || len(all) != len(implementations) {
+		t.Errorf("Expected every implementation, got %d, %v", len(all), err)
+	}
+
+	selected, err := selectImplementations("arc, lru,clock-pro")
+	if err != nil {
+		t.Fatalf("selectImplementations failed: %v", err)
+	}
+	var names []string
+	for _, impl := range selected {
+		names = append(names, impl.name)
+	}
+	// The order of the flag is kept
+	if !slices.Equal(names, []string{"arc", "lru", "clock-pro"}) {
+		t.Errorf("Expected [arc lru clock-pro], got %v", names)
+	}
+
+	if _, err := selectImplementations("lru,belady"); err == nil || !strings.Contains(err.Error(), "belady") {
+		t.Errorf("Expected an error naming belady, got %v", err)
+	}
+}
+
+func TestImplementationsReplay(t *testing.T) {
+	accesses := []trace.Access{{Key: "a", Size: 1}, {Key: "a", Size: 1}, {Key: "b", Size: 1}, {Key: "b", Size: 1}}
+
+	// Every implementation keeps the key it just put, even the sharded one with a
+	// single entry per shard, so only the first access of each key misses
+	for _, impl := range implementations {
+		r := trace.Replay(impl.newCache(2), accesses)
+		if r.Requests != 4 || r.Hits != 2 {
+			t.Errorf("%s: expected 2 hits in 4 requests, got %d in %d", impl.name, r.Hits, r.Requests)
+		}
+	}
+}
+
+// testResults two implementations at two capacities, in the order run produces them
+func testResults() ([]implementation, []int, []result) {
+	selected := []implementation{{name: "lru"}, {name: "arc"}}
+	sizes := []int{10, 100}
+	results := []result{
+		{"lru", 10, trace.Result{Requests: 4, Hits: 1, Bytes: 40, HitBytes: 20}},
+		{"arc", 10, trace.Result{Requests: 4, Hits: 2, Bytes: 40, HitBytes: 10}},
+		{"lru", 100, trace.Result{Requests: 4, Hits: 3, Bytes: 40, HitBytes: 30}},
+		{"arc", 100, trace.Result{Requests: 4, Hits: 4, Bytes: 40, HitBytes: 40}},
+	}
+	return selected, sizes, results
+}
+
+func TestWriteTable(t *testing.T) {
+	selected, sizes, results := testResults()
+
+	var buf bytes.Buffer
+	if err := writeTable(&buf, selected, sizes, results, 4); err != nil {
+		t.Fatalf("writeTable failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 5 || lines[0] != "Hit ratio over 4 requests" || lines[1] != "" {
+		t.Fatalf("Expected a title, a blank line, a header and 2 rows, got %q", lines)
+	}
+	expected := [][]string{
+		{"capacity", "lru", "arc"},
+		{"10", "25.00%", "50.00%"},
+		{"100", "75.00%", "100.00%"},
+	}
+	for i, want := range expected {
+		if got := strings.Fields(lines[i+2]); !slices.Equal(got, want) {
+			t.Errorf("Line %d: expected %v, got %v", i+2, want, got)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	_, _, results := testResults()
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, results); err != nil {
+		t.Fatalf("writeCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("The output should be valid CSV: %v", err)
+	}
+	expected := [][]string{
+		{"policy", "capacity", "requests", "hits", "hit_ratio", "byte_hit_ratio"},
+		{"lru", "10", "4", "1", "0.250000", "0.500000"},
+		{"arc", "10", "4", "2", "0.500000", "0.250000"},
+		{"lru", "100", "4", "3", "0.750000", "0.750000"},
+		{"arc", "100", "4", "4", "1.000000", "1.000000"},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d", len(expected), len(records))
+	}
+	for i, want := range expected {
+		if !slices.Equal(records[i], want) {
+			t.Errorf("Record %d: expected %v, got %v", i, want, records[i])
+		}
+	}
+}
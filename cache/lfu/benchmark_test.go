@@ -0,0 +1,57 @@
+package lfu
+
+import (
+	"testing"
+)
+
+// Concurrent benchmarks
+func BenchmarkLFUCacheConcurrentRead(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.Get(42) // Read same key to test concurrent reads
+		}
+	})
+}
+
+func BenchmarkLFUCacheConcurrentWrite(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkLFUCacheConcurrentReadWrite(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 500; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%3 == 0 {
+				cache.Put(i, i)
+			} else {
+				cache.Get(i % 500)
+			}
+			i++
+		}
+	})
+}
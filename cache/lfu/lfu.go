@@ -0,0 +1,201 @@
+package lfu
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Cache LFU cache structure
+type Cache struct {
+	capacity int
+	cache    map[any]*list.Element // key -> element in its frequency bucket
+	buckets  *list.List            // frequency buckets, lowest frequency first
+	mutex    sync.RWMutex
+}
+
+// bucket all entries with the same access frequency
+type bucket struct {
+	freq    int
+	entries *list.List // most recently used first
+}
+
+// entry cache entry
+type entry struct {
+	key    any
+	value  any
+	bucket *list.Element // element of the bucket holding this entry
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new LFU cache
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		cache:    make(map[any]*list.Element),
+		buckets:  list.New(),
+	}
+}
+
+// Get retrieves a value from the cache and increments its frequency
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		e := element.Value.(*entry)
+		c.increment(element)
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache, updating an existing key counts as an access
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		element.Value.(*entry).value = value
+		c.increment(element)
+		return
+	}
+
+	// If the cache is full, remove the least frequently used element
+	if len(c.cache) >= c.capacity {
+		c.removeLeast()
+	}
+
+	// New entries start in the frequency 1 bucket
+	first := c.buckets.Front()
+	if first == nil || first.Value.(*bucket).freq != 1 {
+		first = c.buckets.PushFront(&bucket{freq: 1, entries: list.New()})
+	}
+	e := &entry{key: key, value: value, bucket: first}
+	c.cache[key] = first.Value.(*bucket).entries.PushFront(e)
+}
+
+// increment moves an entry to the bucket for the next frequency
+func (c *Cache) increment(element *list.Element) {
+	e := element.Value.(*entry)
+	current := e.bucket
+	b := current.Value.(*bucket)
+
+	next := current.Next()
+	if next == nil || next.Value.(*bucket).freq != b.freq+1 {
+		next = c.buckets.InsertAfter(&bucket{freq: b.freq + 1, entries: list.New()}, current)
+	}
+
+	b.entries.Remove(element)
+	e.bucket = next
+	c.cache[e.key] = next.Value.(*bucket).entries.PushFront(e)
+
+	if b.entries.Len() == 0 {
+		c.buckets.Remove(current)
+	}
+}
+
+// Remove removes a key from the cache
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		c.removeElement(element)
+		return true
+	}
+	return false
+}
+
+// removeLeast removes the least recently used element of the lowest frequency
+func (c *Cache) removeLeast() {
+	first := c.buckets.Front()
+	if first == nil {
+		return
+	}
+	if oldest := first.Value.(*bucket).entries.Back(); oldest != nil {
+		c.removeElement(oldest)
+	}
+}
+
+// removeElement removes a specific element and drops its bucket once empty
+func (c *Cache) removeElement(element *list.Element) {
+	e := element.Value.(*entry)
+	b := e.bucket.Value.(*bucket)
+	b.entries.Remove(element)
+	if b.entries.Len() == 0 {
+		c.buckets.Remove(e.bucket)
+	}
+	delete(c.cache, e.key)
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.cache)
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cache = make(map[any]*list.Element)
+	c.buckets = list.New()
+}
+
+// Keys returns all keys in the cache, most frequently used first.
+// Keys with the same frequency are ordered most recently used first.
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, len(c.cache))
+	for b := c.buckets.Back(); b != nil; b = b.Prev() {
+		for element := b.Value.(*bucket).entries.Front(); element != nil; element = element.Next() {
+			keys = append(keys, element.Value.(*entry).key)
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without updating its frequency
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
+
+// Frequency returns how many times a key has been accessed since it was put,
+// counting the initial Put, or zero if the key is not in the cache
+func (c *Cache) Frequency(key any) int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*entry).bucket.Value.(*bucket).freq
+	}
+	return 0
+}
@@ -0,0 +1,379 @@
+package lfu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+)
+
+func TestLFUCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit
+	cache.Put("key3", "value3") // This should evict key2 since key1 was used twice
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestLFUCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+
+	// Put, Put and Get all count as accesses
+	if cache.Frequency("key1") != 3 {
+		t.Errorf("Expected frequency 3, got %d", cache.Frequency("key1"))
+	}
+}
+
+func TestLFUCacheEviction(t *testing.T) {
+	cache := New(3)
+
+	// Fill up the cache
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	// Key 1 is used three times, key 3 twice, key 2 only once
+	cache.Get(1)
+	cache.Get(1)
+	cache.Get(3)
+
+	// Add new key, should evict key 2
+	cache.Put(4, "four")
+
+	if _, ok := cache.Get(2); ok {
+		t.Error("key 2 should have been evicted")
+	}
+
+	// Key 4 is now the least frequently used
+	cache.Put(5, "five")
+
+	if cache.Contains(4) {
+		t.Error("key 4 should have been evicted")
+	}
+
+	for _, key := range []int{1, 3, 5} {
+		if !cache.Contains(key) {
+			t.Errorf("key %d should still be in cache", key)
+		}
+	}
+}
+
+func TestLFUCacheTieBreak(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// All keys are used twice, a least recently
+	cache.Get("a")
+	cache.Get("b")
+	cache.Get("c")
+
+	cache.Put("d", 4)
+
+	if cache.Contains("a") {
+		t.Error("a should have been evicted as the least recently used of the least frequent keys")
+	}
+}
+
+func TestLFUCacheScanResistance(t *testing.T) {
+	cache := New(10)
+
+	// A popular working set
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+		cache.Get(i)
+		cache.Get(i)
+	}
+
+	// A one-off scan over many keys
+	for i := 100; i < 200; i++ {
+		cache.Put(i, i)
+	}
+
+	for i := 0; i < 5; i++ {
+		if !cache.Contains(i) {
+			t.Errorf("Popular key %d should survive the scan", i)
+		}
+	}
+}
+
+func TestLFUCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+}
+
+func TestLFUCacheContains(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+
+	if !cache.Contains("key1") {
+		t.Error("Cache should contain key1")
+	}
+
+	if cache.Contains("key2") {
+		t.Error("Cache should not contain key2")
+	}
+}
+
+func TestLFUCachePeek(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+	cache.Get("key2")
+
+	// Peek should not affect frequency
+	if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+		t.Errorf("Peek should return value1, got %v", value)
+	}
+	if cache.Frequency("key1") != 1 {
+		t.Errorf("Peek should not change frequency, got %d", cache.Frequency("key1"))
+	}
+
+	// Add new key, key1 should be evicted (since Peek didn't count as a use)
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+}
+
+func TestLFUCacheKeys(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	cache.Get("b")
+	cache.Get("b")
+	cache.Get("a")
+
+	keys := cache.Keys()
+	expected := []any{"b", "a", "c"}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected %d keys, got %d", len(expected), len(keys))
+	}
+
+	// Check key order (most frequently used first)
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+}
+
+func TestLFUCacheClear(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Cache should be empty after clear")
+	}
+}
+
+func TestLFUCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestLFUCacheFrequency(t *testing.T) {
+	cache := New(2)
+
+	if cache.Frequency("missing") != 0 {
+		t.Errorf("Expected frequency 0 for a missing key, got %d", cache.Frequency("missing"))
+	}
+
+	cache.Put("a", 1)
+	for i := 0; i < 4; i++ {
+		cache.Get("a")
+	}
+
+	if cache.Frequency("a") != 5 {
+		t.Errorf("Expected frequency 5, got %d", cache.Frequency("a"))
+	}
+
+	// A removed and re-added key starts over
+	cache.Remove("a")
+	cache.Put("a", 1)
+	if cache.Frequency("a") != 1 {
+		t.Errorf("Expected frequency 1, got %d", cache.Frequency("a"))
+	}
+}
+
+func TestLFUCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkLFUCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkLFUCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkLFUCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestLFUCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+	numGoroutines := 10
+	numOperations := 100
+
+	// Start multiple goroutines to perform concurrent operations
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+
+			for j := 0; j < numOperations; j++ {
+				key := id*numOperations + j
+
+				// Put operation
+				cache.Put(key, key*2)
+
+				// Get operation
+				if value, ok := cache.Get(key); ok {
+					if value != key*2 {
+						t.Errorf("Expected %d, got %v", key*2, value)
+					}
+				}
+
+				// Peek, Contains and Frequency operations
+				cache.Peek(key)
+				cache.Contains(key)
+				cache.Frequency(key)
+
+				// Remove some keys
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	// Additional goroutine for cache metadata operations
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < numOperations; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
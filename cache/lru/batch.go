@@ -0,0 +1,159 @@
+package lru
+
+import "fmt"
+
+// GetMany retrieves the values of several keys while taking the lock once.
+// values[i] and found[i] are the result of Get(keys[i]), keys are promoted in order
+// so the last key ends up most recently used.
+func (c *TypedCache[K, V]) GetMany(keys []K) (values []V, found []bool) {
+	values = make([]V, len(keys))
+	found = make([]bool, len(keys))
+
+	c.mutex.Lock()
+	for i, key := range keys {
+		values[i], found[i] = c.get(key)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.stats.recordGets(found)
+	c.notify(evicted)
+	return values, found
+}
+
+// PutMany adds keys[i] with values[i] for every i while taking the lock once, as if
+// Put were called for each pair in order. Evictions are applied in a single pass once
+// every pair is in the cache. It returns the keys evicted to make room, which may
+// include keys of the batch itself if it is larger than the cache, but never keys
+// that are in the cache when PutMany returns.
+// It panics if keys and values differ in length.
+func (c *TypedCache[K, V]) PutMany(keys []K, values []V) []K {
+	checkBatch(len(keys), len(values))
+
+	c.mutex.Lock()
+	c.batch = true
+	expiresAt := c.expiresAt(c.ttl)
+	for i, key := range keys {
+		c.put(key, values[i], c.weigh(key, values[i]), expiresAt)
+	}
+	c.trim()
+	c.batch = false
+	evicted := c.takeEvicted()
+	dropped := evictedKeys(evicted, func(key K) bool {
+		_, ok := c.cache[key]
+		return ok
+	})
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return dropped
+}
+
+// RemoveMany removes several keys while taking the lock once and returns how many
+// of them were in the cache
+func (c *TypedCache[K, V]) RemoveMany(keys []K) int {
+	removed := 0
+
+	c.mutex.Lock()
+	for _, key := range keys {
+		if element, ok := c.cache[key]; ok {
+			c.removeElement(element, EvictReasonRemoved)
+			removed++
+		}
+		c.negative.delete(key)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return removed
+}
+
+// GetMany retrieves the values of several keys while taking the mutex once.
+// values[i] and found[i] are the result of Get(keys[i]).
+func (c *SyncMapCache) GetMany(keys []any) (values []any, found []bool) {
+	values = make([]any, len(keys))
+	found = make([]bool, len(keys))
+
+	c.mutex.Lock()
+	for i, key := range keys {
+		if e, ok := c.load(key); ok {
+			c.list.MoveToFront(e.element)
+			values[i], found[i] = e.value, true
+		}
+	}
+	c.mutex.Unlock()
+
+	c.stats.recordGets(found)
+	return values, found
+}
+
+// PutMany adds keys[i] with values[i] for every i while taking the mutex once and
+// evicts in a single pass at the end. It returns the keys evicted to make room.
+// It panics if keys and values differ in length.
+func (c *SyncMapCache) PutMany(keys []any, values []any) []any {
+	checkBatch(len(keys), len(values))
+
+	c.mutex.Lock()
+	c.batch = true
+	for i, key := range keys {
+		c.put(key, values[i])
+	}
+	for c.list.Len() > 0 && c.list.Len() > c.Cap() {
+		c.removeOldest()
+	}
+	c.batch = false
+	evicted := c.takeEvicted()
+	dropped := evictedKeys(evicted, func(key any) bool {
+		_, ok := c.load(key)
+		return ok
+	})
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return dropped
+}
+
+// RemoveMany removes several keys while taking the mutex once and returns how many
+// of them were in the cache
+func (c *SyncMapCache) RemoveMany(keys []any) int {
+	removed := 0
+
+	c.mutex.Lock()
+	for _, key := range keys {
+		if e, ok := c.load(key); ok {
+			c.removeEntry(e, EvictReasonRemoved)
+			removed++
+		}
+		c.negative.delete(key)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return removed
+}
+
+// checkBatch panics if a batch has a different number of keys and values
+func checkBatch(keys, values int) {
+	if keys != values {
+		panic(fmt.Sprintf("lru: PutMany got %d keys and %d values", keys, values))
+	}
+}
+
+// isEviction reports whether the cache dropped an entry on its own, for capacity or expiration
+func isEviction(reason EvictReason) bool {
+	return reason == EvictReasonCapacity || reason == EvictReasonExpired
+}
+
+// evictedKeys returns the keys of entries evicted for capacity or expiration that
+// are no longer in the cache, a batch may put an expired key again
+func evictedKeys[K comparable, V any](entries []evictedEntry[K, V], contains func(K) bool) []K {
+	var keys []K
+	for _, e := range entries {
+		if isEviction(e.reason) && !contains(e.key) {
+			keys = append(keys, e.key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,249 @@
+package lru
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// batchCache the batch methods shared by Cache and SyncMapCache
+type batchCache interface {
+	Get(key any) (any, bool)
+	Put(key, value any)
+	Keys() []any
+	Stats() Stats
+	GetMany(keys []any) ([]any, []bool)
+	PutMany(keys, values []any) []any
+	RemoveMany(keys []any) int
+}
+
+func TestBatchOperations(t *testing.T) {
+	for name, newCache := range map[string]func(capacity int) batchCache{
+		"RWMutex": func(capacity int) batchCache { return New(capacity) },
+		"SyncMap": func(capacity int) batchCache { return NewSyncMap(capacity) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newCache(3)
+
+			if evicted := c.PutMany([]any{1, 2, 3}, []any{"one", "two", "three"}); len(evicted) != 0 {
+				t.Errorf("Nothing should be evicted while the batch fits, got %v", evicted)
+			}
+
+			values, found := c.GetMany([]any{1, 4, 3})
+			if !slices.Equal(found, []bool{true, false, true}) || values[0] != "one" || values[1] != nil || values[2] != "three" {
+				t.Errorf("Unexpected GetMany result %v %v", values, found)
+			}
+			// Keys are promoted in order, the last one is the most recent
+			if !slices.Equal(c.Keys(), []any{3, 1, 2}) {
+				t.Errorf("Expected order [3 1 2], got %v", c.Keys())
+			}
+			if stats := c.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+				t.Errorf("Expected 2 hits and 1 miss, got %+v", stats)
+			}
+
+			// The same keys as Put in a loop are evicted
+			evicted := c.PutMany([]any{4, 1, 5}, []any{"four", "uno", "five"})
+			if !slices.Equal(evicted, []any{2, 3}) {
+				t.Errorf("Expected 2 and 3 to be evicted, got %v", evicted)
+			}
+			if !slices.Equal(c.Keys(), []any{5, 1, 4}) {
+				t.Errorf("Expected order [5 1 4], got %v", c.Keys())
+			}
+			if value, _ := c.Get(1); value != "uno" {
+				t.Errorf("Expected the updated value uno, got %v", value)
+			}
+
+			if removed := c.RemoveMany([]any{4, 5, 42}); removed != 2 {
+				t.Errorf("Expected 2 keys removed, got %d", removed)
+			}
+			if !slices.Equal(c.Keys(), []any{1}) {
+				t.Errorf("Expected only key 1 left, got %v", c.Keys())
+			}
+		})
+	}
+}
+
+func TestPutManyLargerThanCache(t *testing.T) {
+	var callbacks []any
+	cache := NewWithEvict(2, func(key, value any, reason EvictReason) {
+		if reason == EvictReasonCapacity {
+			callbacks = append(callbacks, key)
+		}
+	})
+	cache.Put(0, 0)
+
+	evicted := cache.PutMany([]any{1, 2, 3}, []any{1, 2, 3})
+
+	if !slices.Equal(evicted, []any{0, 1}) {
+		t.Errorf("Expected 0 and 1 to be evicted, got %v", evicted)
+	}
+	if !slices.Equal(callbacks, evicted) {
+		t.Errorf("The callback should see the same evictions, got %v", callbacks)
+	}
+	if cache.Len() != 2 || !slices.Equal(cache.Keys(), []any{3, 2}) {
+		t.Errorf("Expected keys [3 2], got %v", cache.Keys())
+	}
+}
+
+func TestPutManyRespectsCost(t *testing.T) {
+	cache := NewTypedWithWeigher(10, func(key string, value int) int64 { return int64(value) })
+	cache.Put("a", 4)
+	cache.Put("b", 4)
+
+	evicted := cache.PutMany([]string{"c", "d"}, []int{3, 3})
+
+	// 4+4+3+3 is over the budget of 10 until the least recent a goes
+	if !slices.Equal(evicted, []string{"a"}) {
+		t.Errorf("Expected a to be evicted, got %v", evicted)
+	}
+	if cache.TotalCost() != 10 {
+		t.Errorf("Expected total cost 10, got %d", cache.TotalCost())
+	}
+}
+
+func TestGetManyExpired(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewTypedWithTTL[string, int](10, time.Minute)
+	cache.now = clock.Now
+
+	cache.PutMany([]string{"a", "b"}, []int{1, 2})
+	clock.Advance(2 * time.Minute)
+	cache.Put("c", 3)
+
+	_, found := cache.GetMany([]string{"a", "b", "c"})
+	if !slices.Equal(found, []bool{false, false, true}) {
+		t.Errorf("Expired keys should miss, got %v", found)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expired keys should be dropped, length %d", cache.Len())
+	}
+}
+
+func TestPutManyReplacesExpired(t *testing.T) {
+	clock := newFakeClock()
+	var expired []string
+	cache := NewTypedWithEvict(10, func(key string, value int, reason EvictReason) {
+		if reason == EvictReasonExpired {
+			expired = append(expired, key)
+		}
+	})
+	cache.now = clock.Now
+	cache.PutWithTTL("a", 1, time.Minute)
+	clock.Advance(2 * time.Minute)
+
+	// The expired entry is dropped and a is put again, so it was not evicted
+	if evicted := cache.PutMany([]string{"a"}, []int{2}); len(evicted) != 0 {
+		t.Errorf("Expected no evicted keys, got %v", evicted)
+	}
+	if value, ok := cache.Get("a"); !ok || value != 2 {
+		t.Errorf("Expected a=2 in the cache, got %v, %v", value, ok)
+	}
+	// The callback still sees the old entry expire
+	if !slices.Equal(expired, []string{"a"}) {
+		t.Errorf("Expected an expired callback for a, got %v", expired)
+	}
+}
+
+func TestPutManyMismatchedLengths(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("PutMany should panic when keys and values differ in length")
+		}
+	}()
+	New(10).PutMany([]any{1, 2}, []any{1})
+}
+
+// Benchmarks comparing batch methods with a loop of single calls
+const batchSize = 32
+
+func batchKeys() []any {
+	keys := make([]any, batchSize)
+	for i := range keys {
+		keys[i] = i * 31 % 1000
+	}
+	return keys
+}
+
+func BenchmarkGetLoop(b *testing.B) {
+	cache := New(1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	keys := batchKeys()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			cache.Get(key)
+		}
+	}
+}
+
+func BenchmarkGetMany(b *testing.B) {
+	cache := New(1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	keys := batchKeys()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.GetMany(keys)
+	}
+}
+
+func BenchmarkPutLoop(b *testing.B) {
+	cache := New(1000)
+	keys := batchKeys()
+	values := make([]any, batchSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j, key := range keys {
+			cache.Put(key, values[j])
+		}
+	}
+}
+
+func BenchmarkPutMany(b *testing.B) {
+	cache := New(1000)
+	keys := batchKeys()
+	values := make([]any, batchSize)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.PutMany(keys, values)
+	}
+}
+
+func BenchmarkGetManyConcurrent(b *testing.B) {
+	cache := New(1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	keys := batchKeys()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.GetMany(keys)
+		}
+	})
+}
+
+func BenchmarkGetLoopConcurrent(b *testing.B) {
+	cache := New(1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	keys := batchKeys()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for _, key := range keys {
+				cache.Get(key)
+			}
+		}
+	})
+}
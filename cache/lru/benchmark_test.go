@@ -1,8 +1,16 @@
 package lru
 
 import (
+	"fmt"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/clock"
+	"github.com/loveRyujin/go-algorithm/cache/workload"
+	"github.com/loveRyujin/go-algorithm/cache/workload/workloadtest"
 )
 
 // Benchmark tests for sync.Map implementation
@@ -76,6 +84,39 @@ func BenchmarkSyncMapCacheConcurrentRead(b *testing.B) {
 	})
 }
 
+// CLOCK variants only set a reference bit on a hit, so their reads share a read lock
+func BenchmarkClockCacheConcurrentRead(b *testing.B) {
+	cache := clock.New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.Get(42) // Read same key to test concurrent reads
+		}
+	})
+}
+
+func BenchmarkClockProCacheConcurrentRead(b *testing.B) {
+	cache := clock.NewPro(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.Get(42) // Read same key to test concurrent reads
+		}
+	})
+}
+
 func BenchmarkRWMutexCacheConcurrentWrite(b *testing.B) {
 	cache := New(1000)
 	b.ResetTimer()
@@ -146,6 +187,183 @@ func BenchmarkSyncMapCacheConcurrentReadWrite(b *testing.B) {
 	})
 }
 
+// Concurrent benchmarks for the type-safe implementation (no interface boxing)
+func BenchmarkTypedCacheConcurrentRead(b *testing.B) {
+	cache := NewTyped[int, int](1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			cache.Get(42) // Read same key to test concurrent reads
+		}
+	})
+}
+
+func BenchmarkTypedCacheConcurrentWrite(b *testing.B) {
+	cache := NewTyped[int, int](1000)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkTypedCacheConcurrentReadWrite(b *testing.B) {
+	cache := NewTyped[int, int](1000)
+
+	// Pre-populate cache
+	for i := 0; i < 500; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%3 == 0 {
+				cache.Put(i, i)
+			} else {
+				cache.Get(i % 500)
+			}
+			i++
+		}
+	})
+}
+
+// Concurrent benchmarks for the sharded implementation
+func BenchmarkShardedCacheConcurrentRead(b *testing.B) {
+	cache := NewSharded(1000, 0)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Get(i % 1000) // Spread reads so they land on different shards
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCacheConcurrentWrite(b *testing.B) {
+	cache := NewSharded(1000, 0)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Put(i, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedCacheConcurrentReadWrite(b *testing.B) {
+	cache := NewSharded(1000, 0)
+
+	// Pre-populate cache
+	for i := 0; i < 500; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%3 == 0 {
+				cache.Put(i, i)
+			} else {
+				cache.Get(i % 500)
+			}
+			i++
+		}
+	})
+}
+
+// getPutCache the subset of methods the scaling benchmark needs
+type getPutCache interface {
+	Get(key any) (any, bool)
+	Put(key, value any)
+}
+
+// BenchmarkConcurrentScaling shows how read/write throughput of each implementation
+// scales with GOMAXPROCS. Compare ns/op across the procs sub-benchmarks.
+func BenchmarkConcurrentScaling(b *testing.B) {
+	implementations := []struct {
+		name string
+		new  func() getPutCache
+	}{
+		{"RWMutex", func() getPutCache { return New(1000) }},
+		{"SyncMap", func() getPutCache { return NewSyncMap(1000) }},
+		{"Sharded", func() getPutCache { return NewSharded(1000, 16) }},
+	}
+
+	for _, impl := range implementations {
+		for _, procs := range []int{1, 2, 4, 8, 16} {
+			b.Run(fmt.Sprintf("%s/procs=%d", impl.name, procs), func(b *testing.B) {
+				defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+				cache := impl.new()
+				for i := 0; i < 1000; i++ {
+					cache.Put(i, i)
+				}
+
+				var workers atomic.Int64
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					// Start each goroutine at a different key so they don't move in lockstep
+					i := int(workers.Add(1)) * 997
+					for pb.Next() {
+						if i%4 == 0 {
+							cache.Put(i%2000, i)
+						} else {
+							cache.Get(i % 1000)
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
+// BenchmarkWorkloads replays the synthetic workloads against both implementations,
+// reporting throughput and hit ratio. Run with -bench=Workloads -cpu=1,4,8.
+func BenchmarkWorkloads(b *testing.B) {
+	const capacity = 1000
+	const keySpace = 10 * capacity
+
+	implementations := []struct {
+		name string
+		new  func() cache.Cache
+	}{
+		{"RWMutex", func() cache.Cache { return New(capacity) }},
+		{"SyncMap", func() cache.Cache { return NewSyncMap(capacity) }},
+	}
+
+	for _, w := range workload.Standard(keySpace) {
+		keys := workload.Keys(w.New(1), 1<<16)
+		for _, impl := range implementations {
+			b.Run(w.Name+"/"+impl.name, func(b *testing.B) {
+				workloadtest.Benchmark(b, impl.new(), keys)
+			})
+		}
+	}
+}
+
 // Test sync.Map implementation for correctness
 func TestSyncMapCache(t *testing.T) {
 	cache := NewSyncMap(2)
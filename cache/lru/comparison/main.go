@@ -2,10 +2,17 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/arc"
 	"github.com/loveRyujin/go-algorithm/cache/lru"
+	"github.com/loveRyujin/go-algorithm/cache/trace"
+	"github.com/loveRyujin/go-algorithm/cache/workload"
 )
 
 func main() {
@@ -18,50 +25,39 @@ func main() {
 	testSyncMapImplementation()
 	fmt.Println()
 	comparePerformance()
+	fmt.Println()
+	compareHitRatio()
+	fmt.Println()
+	compareWorkloads()
 }
 
 func testRWMutexImplementation() {
 	fmt.Println("1. RWMutex Implementation:")
-	cache := lru.New(3)
-
-	// Basic operations
-	cache.Put("apple", "red")
-	cache.Put("banana", "yellow")
-	cache.Put("cherry", "red")
-
-	fmt.Printf("   Keys: %v\n", cache.Keys())
-
-	if value, ok := cache.Get("apple"); ok {
-		fmt.Printf("   Get apple: %v\n", value)
-	}
-
-	fmt.Printf("   Keys after accessing apple: %v\n", cache.Keys())
-
-	// Test eviction
-	cache.Put("date", "brown")
-	fmt.Printf("   Keys after adding date: %v\n", cache.Keys())
+	testImplementation(lru.New(3))
 }
 
 func testSyncMapImplementation() {
 	fmt.Println("2. sync.Map Implementation:")
-	cache := lru.NewSyncMap(3)
+	testImplementation(lru.NewSyncMap(3))
+}
 
+func testImplementation(c cache.Cache) {
 	// Basic operations
-	cache.Put("apple", "red")
-	cache.Put("banana", "yellow")
-	cache.Put("cherry", "red")
+	c.Put("apple", "red")
+	c.Put("banana", "yellow")
+	c.Put("cherry", "red")
 
-	fmt.Printf("   Keys: %v\n", cache.Keys())
+	fmt.Printf("   Keys: %v\n", c.Keys())
 
-	if value, ok := cache.Get("apple"); ok {
+	if value, ok := c.Get("apple"); ok {
 		fmt.Printf("   Get apple: %v\n", value)
 	}
 
-	fmt.Printf("   Keys after accessing apple: %v\n", cache.Keys())
+	fmt.Printf("   Keys after accessing apple: %v\n", c.Keys())
 
 	// Test eviction
-	cache.Put("date", "brown")
-	fmt.Printf("   Keys after adding date: %v\n", cache.Keys())
+	c.Put("date", "brown")
+	fmt.Printf("   Keys after adding date: %v\n", c.Keys())
 }
 
 func comparePerformance() {
@@ -70,8 +66,30 @@ func comparePerformance() {
 	const numGoroutines = 50
 	const numOperations = 1000
 
-	// Test RWMutex implementation
 	rwCache := lru.New(100)
+	rwDuration := runConcurrentWorkload(rwCache, numGoroutines, numOperations)
+
+	syncCache := lru.NewSyncMap(100)
+	syncDuration := runConcurrentWorkload(syncCache, numGoroutines, numOperations)
+
+	fmt.Printf("   RWMutex implementation: %v\n", rwDuration)
+	fmt.Printf("   sync.Map implementation: %v\n", syncDuration)
+
+	if rwDuration < syncDuration {
+		fmt.Printf("   RWMutex is %.1f%% faster\n", float64(syncDuration-rwDuration)/float64(syncDuration)*100)
+	} else {
+		fmt.Printf("   sync.Map is %.1f%% faster\n", float64(rwDuration-syncDuration)/float64(rwDuration)*100)
+	}
+
+	fmt.Printf("\n   Final cache sizes - RWMutex: %d, sync.Map: %d\n", rwCache.Len(), syncCache.Len())
+
+	fmt.Println()
+	printStats("RWMutex", rwCache.Stats())
+	printStats("sync.Map", syncCache.Stats())
+}
+
+// runConcurrentWorkload runs the same mix of Put and Get on any cache and returns how long it took
+func runConcurrentWorkload(c cache.Cache, numGoroutines, numOperations int) time.Duration {
 	start := time.Now()
 
 	var wg sync.WaitGroup
@@ -81,44 +99,96 @@ func comparePerformance() {
 			defer wg.Done()
 			for j := 0; j < numOperations; j++ {
 				if j%3 == 0 {
-					rwCache.Put(id*numOperations+j, j)
+					c.Put(id*numOperations+j, j)
 				} else {
-					rwCache.Get(id*100 + j%100)
+					c.Get(id*100 + j%100)
 				}
 			}
 		}(i)
 	}
 	wg.Wait()
-	rwDuration := time.Since(start)
 
-	// Test sync.Map implementation
-	syncCache := lru.NewSyncMap(100)
-	start = time.Now()
+	return time.Since(start)
+}
 
+func printStats(name string, stats lru.Stats) {
+	fmt.Printf("   %s stats: hits=%d misses=%d puts=%d updates=%d evictions=%d removals=%d hit ratio=%.1f%%\n",
+		name, stats.Hits, stats.Misses, stats.Puts, stats.Updates, stats.Evictions, stats.Removals, stats.HitRatio()*100)
+}
+
+func compareHitRatio() {
+	fmt.Println("4. Hit Ratio Comparison (LRU vs ARC):")
+
+	const capacity = 100
+	accesses := generateTrace(100000)
+
+	lruResult := trace.Replay(lru.New(capacity), accesses)
+	arcResult := trace.Replay(arc.New(capacity), accesses)
+
+	fmt.Printf("   Trace: %d requests, capacity %d\n", len(accesses), capacity)
+	fmt.Printf("   LRU hit ratio: %.1f%%\n", lruResult.HitRatio()*100)
+	fmt.Printf("   ARC hit ratio: %.1f%%\n", arcResult.HitRatio()*100)
+}
+
+// generateTrace builds a trace of a skewed hot set interrupted by one-off scans
+func generateTrace(n int) []trace.Access {
+	r := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(r, 1.1, 1, 999)
+
+	accesses := make([]trace.Access, 0, n)
+	next := 1000 // scan keys never repeat
+	for len(accesses) < n {
+		if r.Intn(100) == 0 {
+			for i := 0; i < 200 && len(accesses) < n; i++ {
+				accesses = append(accesses, trace.Access{Key: strconv.Itoa(next), Size: 1})
+				next++
+			}
+			continue
+		}
+		accesses = append(accesses, trace.Access{Key: strconv.FormatUint(zipf.Uint64(), 10), Size: 1})
+	}
+	return accesses
+}
+
+func compareWorkloads() {
+	fmt.Println("5. Synthetic Workloads (RWMutex vs sync.Map):")
+
+	const capacity = 100
+	const keySpace = 10 * capacity
+	const numGoroutines = 8
+	const numRequests = 100000
+
+	fmt.Printf("   %d requests over %d keys, capacity %d, %d goroutines\n", numRequests, keySpace, capacity, numGoroutines)
+	for _, w := range workload.Standard(keySpace) {
+		keys := workload.Keys(w.New(1), numRequests)
+		rwDuration, rwRatio := replayConcurrent(lru.New(capacity), keys, numGoroutines)
+		syncDuration, syncRatio := replayConcurrent(lru.NewSyncMap(capacity), keys, numGoroutines)
+		fmt.Printf("   %-16s RWMutex: %5.1f%% in %-12v sync.Map: %5.1f%% in %v\n",
+			w.Name, rwRatio*100, rwDuration, syncRatio*100, syncDuration)
+	}
+}
+
+// replayConcurrent splits keys between goroutines that replay their part as a
+// read-through cache, and returns how long it took and the hit ratio
+func replayConcurrent(c cache.Cache, keys []int, numGoroutines int) (time.Duration, float64) {
+	start := time.Now()
+
+	var hits atomic.Int64
+	var wg sync.WaitGroup
 	for i := 0; i < numGoroutines; i++ {
 		wg.Add(1)
-		go func(id int) {
+		go func(part []int) {
 			defer wg.Done()
-			for j := 0; j < numOperations; j++ {
-				if j%3 == 0 {
-					syncCache.Put(id*numOperations+j, j)
+			for _, key := range part {
+				if _, ok := c.Get(key); ok {
+					hits.Add(1)
 				} else {
-					syncCache.Get(id*100 + j%100)
+					c.Put(key, key)
 				}
 			}
-		}(i)
+		}(keys[i*len(keys)/numGoroutines : (i+1)*len(keys)/numGoroutines])
 	}
 	wg.Wait()
-	syncDuration := time.Since(start)
-
-	fmt.Printf("   RWMutex implementation: %v\n", rwDuration)
-	fmt.Printf("   sync.Map implementation: %v\n", syncDuration)
 
-	if rwDuration < syncDuration {
-		fmt.Printf("   RWMutex is %.1f%% faster\n", float64(syncDuration-rwDuration)/float64(syncDuration)*100)
-	} else {
-		fmt.Printf("   sync.Map is %.1f%% faster\n", float64(rwDuration-syncDuration)/float64(rwDuration)*100)
-	}
-
-	fmt.Printf("\n   Final cache sizes - RWMutex: %d, sync.Map: %d\n", rwCache.Len(), syncCache.Len())
+	return time.Since(start), float64(hits.Load()) / float64(len(keys))
 }
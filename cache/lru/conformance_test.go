@@ -0,0 +1,24 @@
+package lru
+
+import (
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+)
+
+func TestCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+func TestSyncMapCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return NewSyncMap(capacity) })
+}
+
+func TestShardedCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return NewSharded(capacity, 4) })
+}
+
+func TestSLRUCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return NewSLRU(capacity) })
+}
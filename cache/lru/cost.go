@@ -0,0 +1,62 @@
+package lru
+
+import "math"
+
+// Weigher returns the cost of an entry, for example the size of the value in bytes
+type Weigher[K comparable, V any] func(key K, value V) int64
+
+// NewWithWeigher creates a new LRU cache bounded by the total cost of its entries
+// instead of their number. Put weighs entries with weigher; a nil weigher makes every
+// entry cost 1 unless PutWithCost is used. Cap reports math.MaxInt for such a cache.
+// A maxCost of zero or less sets no budget, leaving the cache unbounded; use
+// NewWithOptions with WithWeigher to reject it instead. Negative costs count as zero.
+func NewWithWeigher(maxCost int64, weigher Weigher[any, any]) *Cache {
+	return NewTypedWithWeigher(maxCost, weigher)
+}
+
+// NewTypedWithWeigher creates a new type-safe LRU cache bounded by the total cost of its entries
+func NewTypedWithWeigher[K comparable, V any](maxCost int64, weigher Weigher[K, V]) *TypedCache[K, V] {
+	c := NewTyped[K, V](math.MaxInt)
+	c.maxCost = max(maxCost, 0)
+	c.weigher = weigher
+	return c
+}
+
+// PutWithCost adds a key-value pair with an explicit cost, evicting least recently used
+// entries until it fits. An entry that costs more than the whole budget is rejected,
+// any existing entry for the key is removed, and PutWithCost reports false.
+// A negative cost counts as zero.
+func (c *TypedCache[K, V]) PutWithCost(key K, value V, cost int64) bool {
+	c.mutex.Lock()
+	ok := c.put(key, value, cost, c.expiresAt(c.ttl))
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return ok
+}
+
+// TotalCost returns the total cost of the entries in the cache
+func (c *TypedCache[K, V]) TotalCost() int64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.totalCost
+}
+
+// MaxCost returns the cost budget of the cache, zero if it is only bounded by capacity
+func (c *TypedCache[K, V]) MaxCost() int64 {
+	return c.maxCost
+}
+
+// weigh returns the cost of an entry put without an explicit cost
+func (c *TypedCache[K, V]) weigh(key K, value V) int64 {
+	if c.weigher != nil {
+		return c.weigher(key, value)
+	}
+	return 1
+}
+
+// overCost reports whether adding extra would exceed the cost budget
+func (c *TypedCache[K, V]) overCost(extra int64) bool {
+	return c.maxCost > 0 && c.totalCost+extra > c.maxCost
+}
@@ -0,0 +1,152 @@
+package lru
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLRUCacheWeigher(t *testing.T) {
+	cache := NewWithWeigher(10, func(key, value any) int64 {
+		return int64(len(value.(string)))
+	})
+
+	cache.Put("a", "aaaa") // cost 4
+	cache.Put("b", "bbbb") // cost 4
+	cache.Get("a")
+
+	if cache.TotalCost() != 8 {
+		t.Errorf("Expected total cost 8, got %d", cache.TotalCost())
+	}
+
+	// cost 6 does not fit next to both, b is the least recently used
+	cache.Put("c", "cccccc")
+
+	if cache.Contains("b") {
+		t.Error("b should have been evicted")
+	}
+	if !cache.Contains("a") || !cache.Contains("c") {
+		t.Errorf("a and c should be in cache, got %v", cache.Keys())
+	}
+	if cache.TotalCost() != 10 {
+		t.Errorf("Expected total cost 10, got %d", cache.TotalCost())
+	}
+	if cache.Cap() != math.MaxInt {
+		t.Errorf("A cost-bounded cache should not limit the entry count, got capacity %d", cache.Cap())
+	}
+}
+
+func TestLRUCacheEvictUntilFits(t *testing.T) {
+	cache := NewWithWeigher(10, nil)
+
+	for i := 0; i < 5; i++ {
+		cache.PutWithCost(i, i, 2)
+	}
+
+	// Evicting a single entry is not enough, the three oldest have to go
+	if !cache.PutWithCost("big", "big", 6) {
+		t.Fatal("An entry within the budget should be accepted")
+	}
+
+	keys := cache.Keys()
+	expected := []any{"big", 4, 3}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+}
+
+func TestLRUCacheUpdateCost(t *testing.T) {
+	cache := NewWithWeigher(10, nil)
+
+	cache.PutWithCost("a", 1, 3)
+	cache.PutWithCost("b", 2, 3)
+	cache.PutWithCost("c", 3, 3)
+
+	// Growing c pushes the oldest entries out
+	cache.PutWithCost("c", 30, 8)
+
+	if cache.Len() != 1 || !cache.Contains("c") {
+		t.Errorf("Expected only c, got %v", cache.Keys())
+	}
+	if cache.TotalCost() != 8 {
+		t.Errorf("Expected total cost 8, got %d", cache.TotalCost())
+	}
+}
+
+func TestLRUCacheRejectOversized(t *testing.T) {
+	cache := NewWithWeigher(10, nil)
+
+	cache.PutWithCost("a", 1, 5)
+	cache.PutWithCost("b", 2, 5)
+
+	if cache.PutWithCost("huge", 3, 11) {
+		t.Error("An entry bigger than the budget should be rejected")
+	}
+	if cache.Len() != 2 || cache.TotalCost() != 10 {
+		t.Errorf("A rejected entry should not evict anything, got %v", cache.Keys())
+	}
+
+	// Rejecting a new value for an existing key must not leave the old value behind
+	if cache.PutWithCost("a", 10, 11) {
+		t.Error("An entry bigger than the budget should be rejected")
+	}
+	if cache.Contains("a") {
+		t.Error("The stale value of a should have been removed")
+	}
+	if cache.TotalCost() != 5 {
+		t.Errorf("Expected total cost 5, got %d", cache.TotalCost())
+	}
+}
+
+func TestLRUCacheCostClearAndRemove(t *testing.T) {
+	cache := NewWithWeigher(10, nil)
+
+	cache.PutWithCost("a", 1, 4)
+	cache.PutWithCost("b", 2, 4)
+	cache.Remove("a")
+
+	if cache.TotalCost() != 4 {
+		t.Errorf("Expected total cost 4 after remove, got %d", cache.TotalCost())
+	}
+
+	cache.Clear()
+
+	if cache.TotalCost() != 0 {
+		t.Errorf("Expected total cost 0 after clear, got %d", cache.TotalCost())
+	}
+}
+
+func TestLRUCacheNegativeCost(t *testing.T) {
+	cache := NewTypedWithWeigher[int, int](10, func(key, value int) int64 { return int64(value) })
+
+	cache.PutWithCost(-1, 0, -1000)
+	cache.Put(-2, -50) // the weigher returns -50
+	for i := 0; i < 100; i++ {
+		cache.PutWithCost(i, i, 1)
+	}
+
+	if cache.TotalCost() != 10 || cache.Len() != 10 {
+		t.Errorf("Negative costs should count as zero, got %d entries costing %d", cache.Len(), cache.TotalCost())
+	}
+}
+
+func TestLRUCacheNonPositiveMaxCost(t *testing.T) {
+	for _, maxCost := range []int64{0, -1} {
+		cache := NewWithWeigher(maxCost, nil)
+
+		for i := 0; i < 1000; i++ {
+			cache.Put(i, i)
+		}
+
+		// No budget is set, the cache is unbounded
+		if cache.MaxCost() != 0 || cache.Len() != 1000 {
+			t.Errorf("NewWithWeigher(%d): expected an unbounded cache, got MaxCost %d and %d entries",
+				maxCost, cache.MaxCost(), cache.Len())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package lru
+
+// EvictReason describes why an entry left the cache
+type EvictReason int
+
+const (
+	// EvictReasonCapacity the entry was the least recently used one when space was needed
+	EvictReasonCapacity EvictReason = iota
+	// EvictReasonRemoved the entry was removed with Remove
+	EvictReasonRemoved
+	// EvictReasonCleared the entry was dropped by Clear
+	EvictReasonCleared
+	// EvictReasonReplaced the entry's value was overwritten by Put
+	EvictReasonReplaced
+	// EvictReasonExpired the entry outlived its time to live
+	EvictReasonExpired
+)
+
+// String returns the name of the reason
+func (r EvictReason) String() string {
+	switch r {
+	case EvictReasonCapacity:
+		return "capacity"
+	case EvictReasonRemoved:
+		return "removed"
+	case EvictReasonCleared:
+		return "cleared"
+	case EvictReasonReplaced:
+		return "replaced"
+	case EvictReasonExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictCallback is called for every entry that leaves the cache.
+// It runs after the cache lock has been released, so it may call back into the cache.
+type EvictCallback[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// evictedEntry an entry that left the cache while the lock was held
+type evictedEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// notifyEvicted runs the callback for entries collected under the lock
+func notifyEvicted[K comparable, V any](onEvict EvictCallback[K, V], entries []evictedEntry[K, V]) {
+	for _, e := range entries {
+		onEvict(e.key, e.value, e.reason)
+	}
+}
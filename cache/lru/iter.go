@@ -0,0 +1,138 @@
+package lru
+
+import (
+	"container/list"
+	"iter"
+)
+
+// All returns an iterator over the entries, most recently used first.
+// Expired entries are skipped.
+//
+// When the loop starts the iterator copies the entries under the read lock and
+// yields them after releasing it, so each loop sees a point-in-time snapshot:
+// writes made meanwhile, including by the loop body, are not reflected. The loop
+// body may call any method of the cache, and a slow loop does not block writers.
+// Iterating does not update the access order or the statistics.
+func (c *TypedCache[K, V]) All() iter.Seq2[K, V] {
+	return c.entries((*list.Element).Next, func(l *list.List) *list.Element { return l.Front() })
+}
+
+// Backward returns an iterator over the entries, least recently used first.
+// Expired entries are skipped. Like All it yields a snapshot taken when the loop starts.
+func (c *TypedCache[K, V]) Backward() iter.Seq2[K, V] {
+	return c.entries((*list.Element).Prev, func(l *list.List) *list.Element { return l.Back() })
+}
+
+// KeySeq returns an iterator over the keys, most recently used first.
+// Like All it yields a snapshot taken when the loop starts.
+func (c *TypedCache[K, V]) KeySeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range c.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values, most recently used first.
+// Like All it yields a snapshot taken when the loop starts.
+func (c *TypedCache[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range c.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// entries copies the live entries under the read lock, walking the list from first
+// in the direction of next, and yields them once the lock is released
+func (c *TypedCache[K, V]) entries(next func(*list.Element) *list.Element, first func(*list.List) *list.Element) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		c.mutex.RLock()
+		now := c.now()
+		pairs := make([]pair[K, V], 0, c.list.Len())
+		for element := first(c.list); element != nil; element = next(element) {
+			if e := element.Value.(*entry[K, V]); !e.expired(now) {
+				pairs = append(pairs, pair[K, V]{e.key, e.value})
+			}
+		}
+		c.mutex.RUnlock()
+
+		yieldPairs(pairs, yield)
+	}
+}
+
+// All returns an iterator over the entries, most recently used first.
+//
+// When the loop starts the iterator copies the entries under the mutex and yields
+// them after releasing it, so each loop sees a point-in-time snapshot: writes made
+// meanwhile, including by the loop body, are not reflected. The loop body may call
+// any method of the cache, and a slow loop does not block other callers. Iterating
+// does not update the access order or the statistics.
+func (c *SyncMapCache) All() iter.Seq2[any, any] {
+	return c.entries((*list.Element).Next, func(l *list.List) *list.Element { return l.Front() })
+}
+
+// Backward returns an iterator over the entries, least recently used first.
+// Like All it yields a snapshot taken when the loop starts.
+func (c *SyncMapCache) Backward() iter.Seq2[any, any] {
+	return c.entries((*list.Element).Prev, func(l *list.List) *list.Element { return l.Back() })
+}
+
+// KeySeq returns an iterator over the keys, most recently used first.
+// Like All it yields a snapshot taken when the loop starts.
+func (c *SyncMapCache) KeySeq() iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for key := range c.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values, most recently used first.
+// Like All it yields a snapshot taken when the loop starts.
+func (c *SyncMapCache) Values() iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for _, value := range c.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// entries copies the entries under the mutex, walking the list from first in the
+// direction of next, and yields them once the mutex is released
+func (c *SyncMapCache) entries(next func(*list.Element) *list.Element, first func(*list.List) *list.Element) iter.Seq2[any, any] {
+	return func(yield func(any, any) bool) {
+		c.mutex.Lock()
+		pairs := make([]pair[any, any], 0, c.list.Len())
+		for element := first(c.list); element != nil; element = next(element) {
+			e := element.Value.(*syncMapEntry)
+			pairs = append(pairs, pair[any, any]{e.key, e.value})
+		}
+		c.mutex.Unlock()
+
+		yieldPairs(pairs, yield)
+	}
+}
+
+// pair a key and its value copied out of a cache for iteration
+type pair[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// yieldPairs yields pairs in order until yield asks to stop
+func yieldPairs[K comparable, V any](pairs []pair[K, V], yield func(K, V) bool) {
+	for _, p := range pairs {
+		if !yield(p.key, p.value) {
+			return
+		}
+	}
+}
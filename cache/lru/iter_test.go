@@ -0,0 +1,182 @@
+package lru
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// iterCache the iterators shared by Cache and SyncMapCache
+type iterCache interface {
+	Put(key, value any)
+	Get(key any) (any, bool)
+	Keys() []any
+	All() iter.Seq2[any, any]
+	Backward() iter.Seq2[any, any]
+	KeySeq() iter.Seq[any]
+	Values() iter.Seq[any]
+}
+
+func TestIterators(t *testing.T) {
+	for name, newCache := range map[string]func() iterCache{
+		"RWMutex": func() iterCache { return New(3) },
+		"SyncMap": func() iterCache { return NewSyncMap(3) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newCache()
+			c.Put(1, "one")
+			c.Put(2, "two")
+			c.Put(3, "three")
+			c.Get(1) // order is now 1, 3, 2
+
+			keys, vals := collect(c.All())
+			if !slices.Equal(keys, []any{1, 3, 2}) || !slices.Equal(vals, []any{"one", "three", "two"}) {
+				t.Errorf("All: expected keys [1 3 2] with their values, got %v %v", keys, vals)
+			}
+			if keys, _ := collect(c.Backward()); !slices.Equal(keys, []any{2, 3, 1}) {
+				t.Errorf("Backward: expected keys [2 3 1], got %v", keys)
+			}
+			if got := slices.Collect(c.KeySeq()); !slices.Equal(got, c.Keys()) {
+				t.Errorf("KeySeq: expected the same order as Keys %v, got %v", c.Keys(), got)
+			}
+			if got := slices.Collect(c.Values()); !slices.Equal(got, []any{"one", "three", "two"}) {
+				t.Errorf("Values: expected [one three two], got %v", got)
+			}
+
+			// Iterating does not change the access order
+			if !slices.Equal(c.Keys(), []any{1, 3, 2}) {
+				t.Errorf("Iteration should not touch the order, got %v", c.Keys())
+			}
+		})
+	}
+}
+
+// collect gathers the pairs of an iterator in order
+func collect(seq iter.Seq2[any, any]) (keys, values []any) {
+	for key, value := range seq {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
+
+func TestIteratorBreakReleasesLock(t *testing.T) {
+	cache := New(10)
+	syncCache := NewSyncMap(10)
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+		syncCache.Put(i, i)
+	}
+
+	for range cache.All() {
+		break
+	}
+	for range syncCache.Backward() {
+		break
+	}
+
+	// Writers would block forever if the lock were still held
+	done := make(chan struct{})
+	go func() {
+		cache.Put(100, 100)
+		syncCache.Put(100, 100)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Breaking out of the loop should release the lock")
+	}
+}
+
+func TestIteratorLoopBodyUsesCache(t *testing.T) {
+	for name, newCache := range map[string]func() iterCache{
+		"RWMutex": func() iterCache { return New(10) },
+		"SyncMap": func() iterCache { return NewSyncMap(10) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newCache()
+			for i := 0; i < 3; i++ {
+				c.Put(i, i)
+			}
+
+			// The loop body calls the cache, which deadlocks if the lock is still held
+			done := make(chan []any)
+			go func() {
+				var keys []any
+				for key := range c.KeySeq() {
+					c.Get(key)
+					c.Put(key.(int)+100, key)
+					keys = append(keys, key)
+				}
+				done <- keys
+			}()
+
+			select {
+			case keys := <-done:
+				// The loop sees the entries as they were when it started
+				if !slices.Equal(keys, []any{2, 1, 0}) {
+					t.Errorf("Expected the snapshot [2 1 0], got %v", keys)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("The loop body should be able to call the cache")
+			}
+		})
+	}
+}
+
+func TestIteratorSkipsExpired(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewTypedWithTTL[string, int](10, time.Minute)
+	cache.now = clock.Now
+
+	cache.Put("old", 1)
+	clock.Advance(30 * time.Second)
+	cache.Put("new", 2)
+	clock.Advance(45 * time.Second) // "old" has expired
+
+	got := maps.Collect(cache.All())
+	if len(got) != 1 || got["new"] != 2 {
+		t.Errorf("Expected only the live entry, got %v", got)
+	}
+	var backward []string
+	for key := range cache.Backward() {
+		backward = append(backward, key)
+	}
+	if !slices.Equal(backward, []string{"new"}) {
+		t.Errorf("Backward should skip expired entries, got %v", backward)
+	}
+}
+
+func BenchmarkTypedCacheAll(b *testing.B) {
+	cache := NewTyped[int, int](1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, value := range cache.All() {
+			sum += value
+		}
+	}
+}
+
+func BenchmarkTypedCacheKeysPeek(b *testing.B) {
+	cache := NewTyped[int, int](1000)
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sum := 0
+		for _, key := range cache.Keys() {
+			value, _ := cache.Peek(key)
+			sum += value
+		}
+	}
+}
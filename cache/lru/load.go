@@ -0,0 +1,235 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLoaderPanicked is returned to callers that shared a loader call which panicked
+var ErrLoaderPanicked = errors.New("lru: loader panicked")
+
+// flightGroup deduplicates concurrent loads of the same key
+type flightGroup[K comparable, V any] struct {
+	mutex sync.Mutex
+	calls map[K]*flightCall[V]
+}
+
+// flightCall a load in progress or completed
+type flightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// do runs fn once for all concurrent callers with the same key and hands its result to each of them
+func (g *flightGroup[K, V]) do(key K, fn func() (V, error)) (V, error) {
+	g.mutex.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	if g.calls == nil {
+		g.calls = make(map[K]*flightCall[V])
+	}
+	call := &flightCall[V]{done: make(chan struct{}), err: ErrLoaderPanicked}
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	// Release the waiters even if fn panics, they see ErrLoaderPanicked
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+	return call.value, call.err
+}
+
+// negativeCache remembers loader errors for a while, guarded by the owning cache's lock.
+// It holds at most as many errors as the cache holds entries, dropping the oldest first.
+type negativeCache[K comparable] struct {
+	ttl     time.Duration // zero disables negative caching
+	entries map[K]*list.Element
+	order   *list.List // elements hold *negativeEntry[K], oldest first
+}
+
+// negativeEntry a remembered loader error
+type negativeEntry[K comparable] struct {
+	key       K
+	err       error
+	expiresAt time.Time
+}
+
+// get returns the remembered error for key or nil, dropping it if it has expired
+func (n *negativeCache[K]) get(key K, now time.Time) error {
+	element, ok := n.entries[key]
+	if !ok {
+		return nil
+	}
+	e := element.Value.(*negativeEntry[K])
+	if !now.Before(e.expiresAt) {
+		n.remove(element)
+		return nil
+	}
+	return e.err
+}
+
+// put remembers err for key if negative caching is enabled, keeping at most limit errors
+func (n *negativeCache[K]) put(key K, err error, now time.Time, limit int) {
+	if n.ttl <= 0 {
+		return
+	}
+	if n.entries == nil {
+		n.entries = make(map[K]*list.Element)
+		n.order = list.New()
+	}
+	n.delete(key)
+
+	// Drop the oldest errors while they have expired or there is no room. Errors are
+	// usually added with the same ttl, so the oldest ones also expire first.
+	for n.order.Len() > 0 {
+		oldest := n.order.Front()
+		if n.order.Len() < max(limit, 1) && now.Before(oldest.Value.(*negativeEntry[K]).expiresAt) {
+			break
+		}
+		n.remove(oldest)
+	}
+
+	e := &negativeEntry[K]{key: key, err: err, expiresAt: now.Add(n.ttl)}
+	n.entries[key] = n.order.PushBack(e)
+}
+
+// remove forgets one remembered error
+func (n *negativeCache[K]) remove(element *list.Element) {
+	n.order.Remove(element)
+	delete(n.entries, element.Value.(*negativeEntry[K]).key)
+}
+
+// delete forgets the error for key
+func (n *negativeCache[K]) delete(key K) {
+	if element, ok := n.entries[key]; ok {
+		n.remove(element)
+	}
+}
+
+// deleteExpired forgets every expired error
+func (n *negativeCache[K]) deleteExpired(now time.Time) {
+	for _, element := range n.entries {
+		if !now.Before(element.Value.(*negativeEntry[K]).expiresAt) {
+			n.remove(element)
+		}
+	}
+}
+
+// len returns the number of remembered errors
+func (n *negativeCache[K]) len() int {
+	return len(n.entries)
+}
+
+// clear forgets every error
+func (n *negativeCache[K]) clear() {
+	n.entries = nil
+	n.order = nil
+}
+
+// GetOrLoad returns the value for key, calling loader to produce and store it on a miss.
+// Concurrent callers that miss the same key share a single loader call and all receive
+// its result. Loader errors are returned and not stored, unless negative caching is
+// enabled with SetNegativeTTL.
+func (c *TypedCache[K, V]) GetOrLoad(key K, loader func() (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	return c.loads.do(key, func() (V, error) {
+		var zero V
+
+		c.mutex.Lock()
+		// Another caller may have stored a value or an error since the miss
+		if element, ok := c.cache[key]; ok && !element.Value.(*entry[K, V]).expired(c.now()) {
+			value := element.Value.(*entry[K, V]).value
+			c.mutex.Unlock()
+			return value, nil
+		}
+		err := c.negative.get(key, c.now())
+		c.mutex.Unlock()
+		if err != nil {
+			return zero, err
+		}
+
+		value, err := loader()
+		if err != nil {
+			c.mutex.Lock()
+			c.negative.put(key, err, c.now(), c.Cap())
+			c.mutex.Unlock()
+			return zero, err
+		}
+		c.Put(key, value)
+		return value, nil
+	})
+}
+
+// SetNegativeTTL enables negative caching: GetOrLoad remembers a loader error for ttl
+// and returns it for the key without calling the loader again. A Put for the key
+// forgets the error. Zero, the default, disables negative caching.
+func (c *TypedCache[K, V]) SetNegativeTTL(ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.negative.ttl = ttl
+	if ttl <= 0 {
+		c.negative.clear()
+	}
+}
+
+// GetOrLoad returns the value for key, calling loader to produce and store it on a miss.
+// Concurrent callers that miss the same key share a single loader call and all receive
+// its result. Loader errors are returned and not stored, unless negative caching is
+// enabled with SetNegativeTTL.
+func (c *SyncMapCache) GetOrLoad(key any, loader func() (any, error)) (any, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	return c.loads.do(key, func() (any, error) {
+		c.mutex.Lock()
+		// Another caller may have stored a value or an error since the miss
+		if e, ok := c.load(key); ok {
+			c.mutex.Unlock()
+			return e.value, nil
+		}
+		err := c.negative.get(key, c.now())
+		c.mutex.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := loader()
+		if err != nil {
+			c.mutex.Lock()
+			c.negative.put(key, err, c.now(), c.Cap())
+			c.mutex.Unlock()
+			return nil, err
+		}
+		c.Put(key, value)
+		return value, nil
+	})
+}
+
+// SetNegativeTTL enables negative caching: GetOrLoad remembers a loader error for ttl
+// and returns it for the key without calling the loader again. A Put for the key
+// forgets the error. Zero, the default, disables negative caching.
+func (c *SyncMapCache) SetNegativeTTL(ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.negative.ttl = ttl
+	if ttl <= 0 {
+		c.negative.clear()
+	}
+}
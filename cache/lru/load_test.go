@@ -0,0 +1,242 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// loadingCache is implemented by both caches so the same scenarios can check them
+type loadingCache interface {
+	GetOrLoad(key any, loader func() (any, error)) (any, error)
+	SetNegativeTTL(ttl time.Duration)
+	Put(key, value any)
+	Get(key any) (any, bool)
+}
+
+var errBackend = errors.New("backend unavailable")
+
+func testGetOrLoad(t *testing.T, cache loadingCache) {
+	calls := 0
+	loader := func() (any, error) {
+		calls++
+		return "loaded", nil
+	}
+
+	for i := 0; i < 3; i++ {
+		if value, err := cache.GetOrLoad("key", loader); err != nil || value != "loaded" {
+			t.Errorf("Expected loaded, got %v, %v", value, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the loader to run once, ran %d times", calls)
+	}
+	if value, ok := cache.Get("key"); !ok || value != "loaded" {
+		t.Errorf("The loaded value should be stored, got %v", value)
+	}
+}
+
+func testGetOrLoadDeduplicates(t *testing.T, cache loadingCache) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func() (any, error) {
+		calls.Add(1)
+		<-release
+		return "loaded", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := cache.GetOrLoad("hot", loader); err != nil || value != "loaded" {
+				t.Errorf("Expected loaded, got %v, %v", value, err)
+			}
+		}()
+	}
+
+	// Give the goroutines time to pile up behind the first loader call
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Late callers find the stored value, so the loader runs once whatever the timing
+	if calls.Load() != 1 {
+		t.Errorf("Expected the loader to run once, ran %d times", calls.Load())
+	}
+}
+
+func testGetOrLoadError(t *testing.T, cache loadingCache) {
+	calls := 0
+	failing := func() (any, error) {
+		calls++
+		return nil, errBackend
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.GetOrLoad("key", failing); !errors.Is(err, errBackend) {
+			t.Errorf("Expected errBackend, got %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Errors should not be cached by default, loader ran %d times", calls)
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Error("A failed load should not store a value")
+	}
+}
+
+func testGetOrLoadNegativeCaching(t *testing.T, cache loadingCache, clock *fakeClock) {
+	cache.SetNegativeTTL(time.Minute)
+
+	calls := 0
+	failing := func() (any, error) {
+		calls++
+		return nil, errBackend
+	}
+
+	cache.GetOrLoad("key", failing)
+	if _, err := cache.GetOrLoad("key", failing); !errors.Is(err, errBackend) {
+		t.Errorf("Expected the remembered errBackend, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("The error should be remembered, loader ran %d times", calls)
+	}
+
+	clock.Advance(time.Minute)
+	cache.GetOrLoad("key", failing)
+	if calls != 2 {
+		t.Errorf("The remembered error should expire, loader ran %d times", calls)
+	}
+
+	// A Put forgets the error
+	cache.Put("key", "fresh")
+	if value, err := cache.GetOrLoad("key", failing); err != nil || value != "fresh" {
+		t.Errorf("Expected fresh, got %v, %v", value, err)
+	}
+}
+
+func testGetOrLoadNegativeBounded(t *testing.T, cache loadingCache, clock *fakeClock, negativeLen func() int) {
+	cache.SetNegativeTTL(time.Hour)
+
+	calls := 0
+	failing := func() (any, error) {
+		calls++
+		return nil, errBackend
+	}
+
+	// Many distinct failing keys keep no more errors than the cache holds entries
+	for i := 0; i < 1000; i++ {
+		cache.GetOrLoad(i, failing)
+	}
+	if n := negativeLen(); n != 10 {
+		t.Errorf("Expected 10 remembered errors, got %d", n)
+	}
+
+	// The oldest errors were dropped, the newest are still remembered
+	calls = 0
+	cache.GetOrLoad(999, failing)
+	if calls != 0 {
+		t.Error("The newest error should still be remembered")
+	}
+	cache.GetOrLoad(0, failing)
+	if calls != 1 {
+		t.Error("The oldest error should have been dropped")
+	}
+
+	// Expired errors are swept by the next write even without a janitor
+	clock.Advance(2 * time.Hour)
+	cache.GetOrLoad("late", failing)
+	if n := negativeLen(); n != 1 {
+		t.Errorf("Expected only the new error after expiration, got %d", n)
+	}
+}
+
+func testGetOrLoadPanic(t *testing.T, cache loadingCache) {
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("The loader panic should reach the caller")
+			}
+		}()
+		cache.GetOrLoad("key", func() (any, error) {
+			panic("boom")
+		})
+	}()
+
+	// The key is usable again after the panic
+	if value, err := cache.GetOrLoad("key", func() (any, error) { return 1, nil }); err != nil || value != 1 {
+		t.Errorf("Expected 1, got %v, %v", value, err)
+	}
+}
+
+func TestLRUCacheGetOrLoad(t *testing.T) {
+	t.Run("Load", func(t *testing.T) { testGetOrLoad(t, New(10)) })
+	t.Run("Deduplicates", func(t *testing.T) { testGetOrLoadDeduplicates(t, New(10)) })
+	t.Run("Error", func(t *testing.T) { testGetOrLoadError(t, New(10)) })
+	t.Run("NegativeCaching", func(t *testing.T) {
+		clock := newFakeClock()
+		cache := New(10)
+		cache.now = clock.Now
+		testGetOrLoadNegativeCaching(t, cache, clock)
+	})
+	t.Run("NegativeBounded", func(t *testing.T) {
+		clock := newFakeClock()
+		cache := New(10)
+		cache.now = clock.Now
+		testGetOrLoadNegativeBounded(t, cache, clock, cache.negative.len)
+	})
+	t.Run("Panic", func(t *testing.T) { testGetOrLoadPanic(t, New(10)) })
+}
+
+func TestSyncMapCacheGetOrLoad(t *testing.T) {
+	t.Run("Load", func(t *testing.T) { testGetOrLoad(t, NewSyncMap(10)) })
+	t.Run("Deduplicates", func(t *testing.T) { testGetOrLoadDeduplicates(t, NewSyncMap(10)) })
+	t.Run("Error", func(t *testing.T) { testGetOrLoadError(t, NewSyncMap(10)) })
+	t.Run("NegativeCaching", func(t *testing.T) {
+		clock := newFakeClock()
+		cache := NewSyncMap(10)
+		cache.now = clock.Now
+		testGetOrLoadNegativeCaching(t, cache, clock)
+	})
+	t.Run("NegativeBounded", func(t *testing.T) {
+		clock := newFakeClock()
+		cache := NewSyncMap(10)
+		cache.now = clock.Now
+		testGetOrLoadNegativeBounded(t, cache, clock, cache.negative.len)
+	})
+	t.Run("Panic", func(t *testing.T) { testGetOrLoadPanic(t, NewSyncMap(10)) })
+}
+
+func TestFlightGroupPanicReleasesWaiters(t *testing.T) {
+	var group flightGroup[string, int]
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { recover() }()
+		group.do("key", func() (int, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+
+	<-started
+	result := make(chan error)
+	go func() {
+		_, err := group.do("key", func() (int, error) { return 1, nil })
+		result <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+
+	// The waiter either joined the panicking call or ran its own load afterwards
+	if err := <-result; err != nil && !errors.Is(err, ErrLoaderPanicked) {
+		t.Errorf("Expected nil or ErrLoaderPanicked, got %v", err)
+	}
+}
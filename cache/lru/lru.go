@@ -3,146 +3,308 @@ package lru
 import (
 	"container/list"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/loveRyujin/go-algorithm/cache"
 )
 
-// Cache LRU cache structure
-type Cache struct {
-	capacity int
-	cache    map[any]*list.Element
-	list     *list.List
-	mutex    sync.RWMutex
+// Cache LRU cache structure holding keys and values of any type
+type Cache = TypedCache[any, any]
+
+var _ cache.Cache = (*Cache)(nil)
+
+// TypedCache type-safe LRU cache structure
+type TypedCache[K comparable, V any] struct {
+	capacity  atomic.Int64 // written under the lock by Resize, read by Cap without it
+	cache     map[K]*list.Element
+	list      *list.List
+	mutex     sync.RWMutex
+	onEvict   EvictCallback[K, V]
+	evicted   []evictedEntry[K, V] // entries to report once the lock is released
+	batch     bool                 // a PutMany is in progress, see makeRoom
+	ttl       time.Duration        // default time to live, zero means entries never expire
+	now       func() time.Time
+	janitor   *janitor
+	maxCost   int64 // total cost budget, zero means only capacity bounds the cache
+	totalCost int64 // total cost of the entries in the cache
+	weigher   Weigher[K, V]
+	stats     statsCounter
+	loads     flightGroup[K, V]
+	negative  negativeCache[K] // loader errors remembered by GetOrLoad
 }
 
 // entry cache entry
-type entry struct {
-	key   any
-	value any
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // zero means the entry never expires
+	cost      int64
 }
 
-// New creates a new LRU cache
+// expired reports whether the entry is past its expiration time
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// New creates a new LRU cache. A capacity below 1 is not rejected: the cache then
+// keeps only the most recent entry. Use NewWithOptions to validate the capacity.
 func New(capacity int) *Cache {
-	return &Cache{
-		capacity: capacity,
-		cache:    make(map[any]*list.Element),
-		list:     list.New(),
+	return NewTyped[any, any](capacity)
+}
+
+// NewTyped creates a new type-safe LRU cache
+func NewTyped[K comparable, V any](capacity int) *TypedCache[K, V] {
+	c := &TypedCache[K, V]{
+		cache: make(map[K]*list.Element),
+		list:  list.New(),
+		now:   time.Now,
 	}
+	c.capacity.Store(int64(capacity))
+	return c
+}
+
+// NewWithEvict creates a new LRU cache that reports evicted entries to onEvict
+func NewWithEvict(capacity int, onEvict EvictCallback[any, any]) *Cache {
+	return NewTypedWithEvict(capacity, onEvict)
+}
+
+// NewTypedWithEvict creates a new type-safe LRU cache that reports evicted entries to onEvict
+func NewTypedWithEvict[K comparable, V any](capacity int, onEvict EvictCallback[K, V]) *TypedCache[K, V] {
+	c := NewTyped[K, V](capacity)
+	c.onEvict = onEvict
+	return c
 }
 
 // Get retrieves a value from the cache
-func (c *Cache) Get(key any) (any, bool) {
+func (c *TypedCache[K, V]) Get(key K) (V, bool) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	value, ok := c.get(key)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
 
+	c.stats.recordGet(ok)
+	c.notify(evicted)
+	return value, ok
+}
+
+// get retrieves a value and drops it if it has expired, the caller must hold the write lock
+func (c *TypedCache[K, V]) get(key K) (V, bool) {
 	if element, ok := c.cache[key]; ok {
+		e := element.Value.(*entry[K, V])
+		if e.expired(c.now()) {
+			c.removeElement(element, EvictReasonExpired)
+			var zero V
+			return zero, false
+		}
 		// Move the accessed element to the front of the list
 		c.list.MoveToFront(element)
-		return element.Value.(*entry).value, true
+		return e.value, true
 	}
-	return nil, false
+	var zero V
+	return zero, false
 }
 
 // Put adds a key-value pair to the cache
-func (c *Cache) Put(key, value any) {
+func (c *TypedCache[K, V]) Put(key K, value V) {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	c.put(key, value, c.weigh(key, value), c.expiresAt(c.ttl))
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
 
-	if element, ok := c.cache[key]; ok {
-		// If the key already exists, update the value and move to front
-		element.Value.(*entry).value = value
-		c.list.MoveToFront(element)
-		return
+	c.notify(evicted)
+}
+
+// put adds a key-value pair, the caller must hold the write lock.
+// It reports false if the entry costs more than the whole budget and was rejected.
+func (c *TypedCache[K, V]) put(key K, value V, cost int64, expiresAt time.Time) bool {
+	c.negative.delete(key)
+	// A negative cost would let other entries exceed the budget
+	cost = max(cost, 0)
+
+	if c.maxCost > 0 && cost > c.maxCost {
+		// The entry can never fit, drop the old value rather than serve a stale one
+		if element, ok := c.cache[key]; ok {
+			c.removeElement(element, EvictReasonRemoved)
+		}
+		return false
 	}
 
-	// If the cache is full, remove the least recently used element
-	if c.list.Len() >= c.capacity {
-		c.removeOldest()
+	if element, ok := c.cache[key]; ok {
+		e := element.Value.(*entry[K, V])
+		if e.expired(c.now()) {
+			// An expired entry is dropped rather than replaced
+			c.removeElement(element, EvictReasonExpired)
+		} else {
+			// If the key already exists, update the value and move to front
+			c.addEvicted(e.key, e.value, EvictReasonReplaced)
+			c.stats.recordUpdate()
+			c.totalCost += cost - e.cost
+			e.value = value
+			e.expiresAt = expiresAt
+			e.cost = cost
+			c.list.MoveToFront(element)
+			// A heavier value may push older entries out of the budget
+			for c.overCost(0) {
+				c.removeOldest(EvictReasonCapacity)
+			}
+			return true
+		}
 	}
 
+	c.makeRoom(cost)
+
 	// Add new element to the front of the list
-	newEntry := &entry{key: key, value: value}
+	newEntry := &entry[K, V]{key: key, value: value, expiresAt: expiresAt, cost: cost}
 	element := c.list.PushFront(newEntry)
 	c.cache[key] = element
+	c.totalCost += cost
+	c.stats.recordPut()
+	return true
 }
 
 // Remove removes a key from the cache
-func (c *Cache) Remove(key any) bool {
+func (c *TypedCache[K, V]) Remove(key K) bool {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	element, ok := c.cache[key]
+	if ok {
+		c.removeElement(element, EvictReasonRemoved)
+	}
+	c.negative.delete(key)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
 
-	if element, ok := c.cache[key]; ok {
-		c.removeElement(element)
-		return true
+	c.notify(evicted)
+	return ok
+}
+
+// makeRoom removes least recently used elements until a new one of cost fits.
+// During a batch the cache may overflow, trim evicts once the batch is complete.
+func (c *TypedCache[K, V]) makeRoom(cost int64) {
+	if c.batch {
+		return
+	}
+	for c.list.Len() > 0 && (c.list.Len() >= c.Cap() || c.overCost(cost)) {
+		c.removeOldest(EvictReasonCapacity)
+	}
+}
+
+// trim removes least recently used elements until the cache is within its bounds again
+func (c *TypedCache[K, V]) trim() {
+	for c.list.Len() > 0 && (c.list.Len() > c.Cap() || c.overCost(0)) {
+		c.removeOldest(EvictReasonCapacity)
 	}
-	return false
 }
 
 // removeOldest removes the least recently used element (tail of the list)
-func (c *Cache) removeOldest() {
+func (c *TypedCache[K, V]) removeOldest(reason EvictReason) {
 	if c.list.Len() == 0 {
 		return
 	}
 	oldest := c.list.Back()
 	if oldest != nil {
-		c.removeElement(oldest)
+		c.removeElement(oldest, reason)
 	}
 }
 
 // removeElement removes a specific element
-func (c *Cache) removeElement(element *list.Element) {
+func (c *TypedCache[K, V]) removeElement(element *list.Element, reason EvictReason) {
 	c.list.Remove(element)
-	delete(c.cache, element.Value.(*entry).key)
+	e := element.Value.(*entry[K, V])
+	delete(c.cache, e.key)
+	c.totalCost -= e.cost
+	c.stats.recordEvict(reason, 1)
+	c.addEvicted(e.key, e.value, reason)
 }
 
-// Len returns the number of elements in the cache
-func (c *Cache) Len() int {
+// addEvicted records an entry for the eviction callback or a batch, the caller must hold the write lock
+func (c *TypedCache[K, V]) addEvicted(key K, value V, reason EvictReason) {
+	if c.onEvict != nil || (c.batch && isEviction(reason)) {
+		c.evicted = append(c.evicted, evictedEntry[K, V]{key: key, value: value, reason: reason})
+	}
+}
+
+// takeEvicted hands over the recorded entries, the caller must hold the write lock
+func (c *TypedCache[K, V]) takeEvicted() []evictedEntry[K, V] {
+	entries := c.evicted
+	c.evicted = nil
+	return entries
+}
+
+// notify runs the eviction callback, the caller must not hold the lock
+func (c *TypedCache[K, V]) notify(entries []evictedEntry[K, V]) {
+	if c.onEvict != nil {
+		notifyEvicted(c.onEvict, entries)
+	}
+}
+
+// Len returns the number of elements in the cache.
+// Expired entries are counted until they are accessed or swept by the janitor.
+func (c *TypedCache[K, V]) Len() int {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 	return c.list.Len()
 }
 
-// Cap returns the capacity of the cache
-func (c *Cache) Cap() int {
-	// Capacity doesn't change, no lock needed
-	return c.capacity
+// Cap returns the capacity of the cache, it is safe to call during a Resize
+func (c *TypedCache[K, V]) Cap() int {
+	return int(c.capacity.Load())
 }
 
 // Clear removes all elements from the cache
-func (c *Cache) Clear() {
+func (c *TypedCache[K, V]) Clear() {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	c.cache = make(map[any]*list.Element)
+	if c.onEvict != nil {
+		for element := c.list.Front(); element != nil; element = element.Next() {
+			e := element.Value.(*entry[K, V])
+			c.addEvicted(e.key, e.value, EvictReasonCleared)
+		}
+	}
+	c.stats.recordEvict(EvictReasonCleared, uint64(c.list.Len()))
+	c.cache = make(map[K]*list.Element)
 	c.list = list.New()
+	c.totalCost = 0
+	c.negative.clear()
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
 }
 
 // Keys returns all keys in the cache (in access order, most recent first)
-func (c *Cache) Keys() []any {
+func (c *TypedCache[K, V]) Keys() []K {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	keys := make([]any, 0, c.list.Len())
+	now := c.now()
+	keys := make([]K, 0, c.list.Len())
 	for element := c.list.Front(); element != nil; element = element.Next() {
-		keys = append(keys, element.Value.(*entry).key)
+		if e := element.Value.(*entry[K, V]); !e.expired(now) {
+			keys = append(keys, e.key)
+		}
 	}
 	return keys
 }
 
 // Contains checks if the cache contains a specific key
-func (c *Cache) Contains(key any) bool {
+func (c *TypedCache[K, V]) Contains(key K) bool {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	_, ok := c.cache[key]
-	return ok
+	element, ok := c.cache[key]
+	return ok && !element.Value.(*entry[K, V]).expired(c.now())
 }
 
 // Peek looks up a value without updating the access order
-func (c *Cache) Peek(key any) (any, bool) {
+func (c *TypedCache[K, V]) Peek(key K) (V, bool) {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
 	if element, ok := c.cache[key]; ok {
-		return element.Value.(*entry).value, true
+		if e := element.Value.(*entry[K, V]); !e.expired(c.now()) {
+			return e.value, true
+		}
 	}
-	return nil, false
+	var zero V
+	return zero, false
 }
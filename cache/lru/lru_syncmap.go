@@ -3,88 +3,176 @@ package lru
 import (
 	"container/list"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/loveRyujin/go-algorithm/cache"
 )
 
-// SyncMapCache LRU cache using sync.Map
+// SyncMapCache LRU cache using sync.Map.
+//
+// Peek, Contains and missing Gets read the sync.Map without locking. Every write to the
+// sync.Map happens together with the matching list update while holding the mutex,
+// so the map and the list always describe the same set of entries.
 type SyncMapCache struct {
-	capacity int
-	cache    sync.Map // sync.Map for concurrent access
-	list     *list.List
-	mutex    sync.Mutex // Still need mutex for list operations
+	capacity atomic.Int64 // written under the mutex by Resize, read by Cap without it
+	cache    sync.Map     // key -> *syncMapEntry, only written while holding the mutex
+	list     *list.List   // elements hold *syncMapEntry, most recent first
+	mutex    sync.Mutex   // guards the list and every write to the sync.Map
+	onEvict  EvictCallback[any, any]
+	evicted  []evictedEntry[any, any] // entries to report once the mutex is released
+	batch    bool                     // a PutMany is in progress, evictions wait for its end
+	stats    statsCounter
+	now      func() time.Time
+	loads    flightGroup[any, any]
+	negative negativeCache[any] // loader errors remembered by GetOrLoad
+}
+
+// syncMapEntry cache entry for SyncMapCache.
+// Key and value never change after creation so lock-free readers can use them;
+// an update stores a new entry instead of modifying the old one.
+type syncMapEntry struct {
+	key     any
+	value   any
+	element *list.Element // guarded by the mutex
 }
 
+var _ cache.Cache = (*SyncMapCache)(nil)
+
 // NewSyncMap creates a new LRU cache using sync.Map
 func NewSyncMap(capacity int) *SyncMapCache {
-	return &SyncMapCache{
-		capacity: capacity,
-		list:     list.New(),
+	c := &SyncMapCache{
+		list: list.New(),
+		now:  time.Now,
 	}
+	c.capacity.Store(int64(capacity))
+	return c
+}
+
+// NewSyncMapWithEvict creates a new LRU cache using sync.Map that reports evicted entries to onEvict
+func NewSyncMapWithEvict(capacity int, onEvict EvictCallback[any, any]) *SyncMapCache {
+	c := NewSyncMap(capacity)
+	c.onEvict = onEvict
+	return c
 }
 
 // Get retrieves a value from the cache using sync.Map
 func (c *SyncMapCache) Get(key any) (any, bool) {
-	if value, ok := c.cache.Load(key); ok {
-		element := value.(*list.Element)
+	// A miss needs no lock
+	if _, ok := c.cache.Load(key); !ok {
+		c.stats.recordGet(false)
+		return nil, false
+	}
 
-		// Still need to protect list operations
-		c.mutex.Lock()
-		c.list.MoveToFront(element)
-		c.mutex.Unlock()
+	// Load again under the lock, the entry may have been evicted or replaced meanwhile
+	c.mutex.Lock()
+	e, ok := c.load(key)
+	if ok {
+		c.list.MoveToFront(e.element)
+	}
+	c.mutex.Unlock()
 
-		return element.Value.(*entry).value, true
+	c.stats.recordGet(ok)
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	return e.value, true
 }
 
 // Put adds a key-value pair to the cache using sync.Map
 func (c *SyncMapCache) Put(key, value any) {
-	if existingValue, ok := c.cache.Load(key); ok {
-		element := existingValue.(*list.Element)
+	c.mutex.Lock()
+	c.put(key, value)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+}
 
-		c.mutex.Lock()
-		element.Value.(*entry).value = value
-		c.list.MoveToFront(element)
-		c.mutex.Unlock()
+// put adds a key-value pair, the caller must hold the mutex
+func (c *SyncMapCache) put(key, value any) {
+	c.negative.delete(key)
+
+	if old, ok := c.load(key); ok {
+		// Replace the entry rather than modify it, readers may still hold the old one
+		e := &syncMapEntry{key: key, value: value, element: old.element}
+		e.element.Value = e
+		c.cache.Store(key, e)
+		c.list.MoveToFront(e.element)
+		c.addEvicted(old.key, old.value, EvictReasonReplaced)
+		c.stats.recordUpdate()
 		return
 	}
 
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
 	// If cache is full, remove the least recently used element
-	if c.list.Len() >= c.capacity {
+	if c.list.Len() >= c.Cap() && !c.batch {
 		c.removeOldest()
 	}
 
 	// Add new element to the front of the list
-	newEntry := &entry{key: key, value: value}
-	element := c.list.PushFront(newEntry)
-	c.cache.Store(key, element)
+	e := &syncMapEntry{key: key, value: value}
+	e.element = c.list.PushFront(e)
+	c.cache.Store(key, e)
+	c.stats.recordPut()
 }
 
 // Remove removes a key from the cache
 func (c *SyncMapCache) Remove(key any) bool {
-	if value, ok := c.cache.LoadAndDelete(key); ok {
-		element := value.(*list.Element)
+	c.mutex.Lock()
+	e, ok := c.load(key)
+	if ok {
+		c.removeEntry(e, EvictReasonRemoved)
+	}
+	c.negative.delete(key)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
 
-		c.mutex.Lock()
-		c.list.Remove(element)
-		c.mutex.Unlock()
+	c.notify(evicted)
+	return ok
+}
 
-		return true
+// load looks up the entry for key in the sync.Map
+func (c *SyncMapCache) load(key any) (*syncMapEntry, bool) {
+	value, ok := c.cache.Load(key)
+	if !ok {
+		return nil, false
 	}
-	return false
+	return value.(*syncMapEntry), true
 }
 
 // removeOldest removes the least recently used element (tail of the list)
 func (c *SyncMapCache) removeOldest() {
-	if c.list.Len() == 0 {
-		return
+	if oldest := c.list.Back(); oldest != nil {
+		c.removeEntry(oldest.Value.(*syncMapEntry), EvictReasonCapacity)
+	}
+}
+
+// removeEntry removes an entry from both the list and the sync.Map, the caller must hold the mutex
+func (c *SyncMapCache) removeEntry(e *syncMapEntry, reason EvictReason) {
+	c.list.Remove(e.element)
+	c.cache.Delete(e.key)
+	c.stats.recordEvict(reason, 1)
+	c.addEvicted(e.key, e.value, reason)
+}
+
+// addEvicted records an entry for the eviction callback or a batch, the caller must hold the mutex
+func (c *SyncMapCache) addEvicted(key, value any, reason EvictReason) {
+	if c.onEvict != nil || (c.batch && isEviction(reason)) {
+		c.evicted = append(c.evicted, evictedEntry[any, any]{key: key, value: value, reason: reason})
 	}
-	oldest := c.list.Back()
-	if oldest != nil {
-		c.list.Remove(oldest)
-		c.cache.Delete(oldest.Value.(*entry).key)
+}
+
+// takeEvicted hands over the recorded entries, the caller must hold the mutex
+func (c *SyncMapCache) takeEvicted() []evictedEntry[any, any] {
+	entries := c.evicted
+	c.evicted = nil
+	return entries
+}
+
+// notify runs the eviction callback, the caller must not hold the mutex
+func (c *SyncMapCache) notify(entries []evictedEntry[any, any]) {
+	if c.onEvict != nil {
+		notifyEvicted(c.onEvict, entries)
 	}
 }
 
@@ -95,18 +183,28 @@ func (c *SyncMapCache) Len() int {
 	return c.list.Len()
 }
 
-// Cap returns the capacity of the cache
+// Cap returns the capacity of the cache, it is safe to call during a Resize
 func (c *SyncMapCache) Cap() int {
-	return c.capacity
+	return int(c.capacity.Load())
 }
 
 // Clear removes all elements from the cache
 func (c *SyncMapCache) Clear() {
 	c.mutex.Lock()
-	defer c.mutex.Unlock()
-
-	c.cache = sync.Map{}
-	c.list = list.New()
+	if c.onEvict != nil {
+		for element := c.list.Front(); element != nil; element = element.Next() {
+			e := element.Value.(*syncMapEntry)
+			c.addEvicted(e.key, e.value, EvictReasonCleared)
+		}
+	}
+	c.stats.recordEvict(EvictReasonCleared, uint64(c.list.Len()))
+	c.cache.Clear()
+	c.list.Init()
+	c.negative.clear()
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
 }
 
 // Keys returns all keys in the cache (in access order, most recent first)
@@ -116,7 +214,7 @@ func (c *SyncMapCache) Keys() []any {
 
 	keys := make([]any, 0, c.list.Len())
 	for element := c.list.Front(); element != nil; element = element.Next() {
-		keys = append(keys, element.Value.(*entry).key)
+		keys = append(keys, element.Value.(*syncMapEntry).key)
 	}
 	return keys
 }
@@ -129,9 +227,8 @@ func (c *SyncMapCache) Contains(key any) bool {
 
 // Peek looks up a value without updating the access order
 func (c *SyncMapCache) Peek(key any) (any, bool) {
-	if value, ok := c.cache.Load(key); ok {
-		element := value.(*list.Element)
-		return element.Value.(*entry).value, true
+	if e, ok := c.load(key); ok {
+		return e.value, true
 	}
 	return nil, false
 }
@@ -0,0 +1,157 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncMapCacheEvictCallback(t *testing.T) {
+	var records []evictRecord
+	cache := NewSyncMapWithEvict(2, func(key, value any, reason EvictReason) {
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // replace
+	cache.Put("c", 3)  // evicts b
+	cache.Remove("a")
+	cache.Remove("missing")
+	cache.Clear() // drops c
+
+	expected := []evictRecord{
+		{"a", 1, EvictReasonReplaced},
+		{"b", 2, EvictReasonCapacity},
+		{"a", 10, EvictReasonRemoved},
+		{"c", 3, EvictReasonCleared},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d callbacks, got %d: %v", len(expected), len(records), records)
+	}
+	for i, want := range expected {
+		if records[i] != want {
+			t.Errorf("Callback %d: expected %v, got %v", i, want, records[i])
+		}
+	}
+}
+
+// checkSyncMapConsistency verifies that the list and the sync.Map hold exactly the same entries
+func checkSyncMapConsistency(t *testing.T, c *SyncMapCache) {
+	t.Helper()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	mapped := 0
+	c.cache.Range(func(key, value any) bool {
+		mapped++
+		return true
+	})
+	if mapped != c.list.Len() {
+		t.Errorf("List holds %d elements but the map holds %d entries", c.list.Len(), mapped)
+	}
+
+	seen := make(map[any]bool)
+	for element := c.list.Front(); element != nil; element = element.Next() {
+		e := element.Value.(*syncMapEntry)
+		if seen[e.key] {
+			t.Errorf("Key %v appears twice in the list", e.key)
+		}
+		seen[e.key] = true
+		if current, ok := c.load(e.key); !ok || current != e {
+			t.Errorf("List element for key %v does not match the map entry", e.key)
+		}
+	}
+}
+
+func TestSyncMapCacheStress(t *testing.T) {
+	cache := NewSyncMap(32)
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// A small key space makes concurrent Puts of the same new key and
+	// Gets of keys being evicted as likely as possible
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				key := (id + j) % 64
+				switch j % 5 {
+				case 0, 1:
+					cache.Put(key, j)
+				case 2:
+					cache.Get(key)
+				case 3:
+					cache.Peek(key)
+				case 4:
+					if j%15 == 4 {
+						cache.Remove(key)
+					} else {
+						cache.Contains(key)
+					}
+				}
+			}
+		}(i)
+	}
+
+	checker := make(chan struct{})
+	go func() {
+		defer close(checker)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				checkSyncMapConsistency(t, cache)
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+	<-checker
+
+	checkSyncMapConsistency(t, cache)
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestSyncMapCacheStressKeys(t *testing.T) {
+	cache := NewSyncMap(16)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				// Every goroutine races to insert the same new keys
+				cache.Put(j%40, id)
+				cache.Get((j + 1) % 40)
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			keys := cache.Keys()
+			seen := make(map[any]bool, len(keys))
+			for _, key := range keys {
+				if seen[key] {
+					t.Errorf("Keys() returned %v twice", key)
+					return
+				}
+				seen[key] = true
+			}
+			if i%100 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+	checkSyncMapConsistency(t, cache)
+}
@@ -1,6 +1,7 @@
 package lru
 
 import (
+	"slices"
 	"sync"
 	"testing"
 )
@@ -197,6 +198,91 @@ func TestLRUCacheCapacity(t *testing.T) {
 	}
 }
 
+// TestLRUCacheLegacyCapacity pins the behaviour of the legacy constructors for a
+// capacity below 1: they keep only the latest entry and Cap reports the given value
+func TestLRUCacheLegacyCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		capacity int
+		cache    interface {
+			Put(key, value any)
+			Keys() []any
+			Len() int
+			Cap() int
+		}
+	}{
+		{"New(0)", 0, New(0)},
+		{"New(-1)", -1, New(-1)},
+		{"NewSyncMap(0)", 0, NewSyncMap(0)},
+		{"NewSyncMap(-1)", -1, NewSyncMap(-1)},
+	} {
+		tc.cache.Put(1, 1)
+		tc.cache.Put(2, 2)
+		tc.cache.Put(3, 3)
+
+		if !slices.Equal(tc.cache.Keys(), []any{3}) || tc.cache.Len() != 1 {
+			t.Errorf("%s: expected only the latest key, got %v", tc.name, tc.cache.Keys())
+		}
+		if tc.cache.Cap() != tc.capacity {
+			t.Errorf("%s: Cap should report %d, got %d", tc.name, tc.capacity, tc.cache.Cap())
+		}
+	}
+}
+
+type evictRecord struct {
+	key    any
+	value  any
+	reason EvictReason
+}
+
+func TestLRUCacheEvictCallback(t *testing.T) {
+	var records []evictRecord
+	cache := NewWithEvict(2, func(key, value any, reason EvictReason) {
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // replace
+	cache.Put("c", 3)  // evicts b
+	cache.Remove("a")
+	cache.Remove("missing")
+	cache.Clear() // drops c
+
+	expected := []evictRecord{
+		{"a", 1, EvictReasonReplaced},
+		{"b", 2, EvictReasonCapacity},
+		{"a", 10, EvictReasonRemoved},
+		{"c", 3, EvictReasonCleared},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d callbacks, got %d: %v", len(expected), len(records), records)
+	}
+	for i, want := range expected {
+		if records[i] != want {
+			t.Errorf("Callback %d: expected %v, got %v", i, want, records[i])
+		}
+	}
+}
+
+func TestLRUCacheEvictCallbackReentrant(t *testing.T) {
+	var cache *Cache
+	cache = NewWithEvict(1, func(key, value any, reason EvictReason) {
+		// The lock is released before the callback runs, so this must not deadlock
+		if reason == EvictReasonCapacity {
+			cache.Len()
+			cache.Put("last-evicted", key)
+		}
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2) // evicts a, callback puts last-evicted which evicts b
+
+	if value, ok := cache.Get("last-evicted"); !ok || value != "b" {
+		t.Errorf("Expected last-evicted to be b, got %v", value)
+	}
+}
+
 // Benchmark tests
 func BenchmarkLRUCachePut(b *testing.B) {
 	cache := New(1000)
@@ -0,0 +1,248 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	// ErrInvalidCapacity is returned by the WithOptions constructors for a missing
+	// capacity or one below 1
+	ErrInvalidCapacity = errors.New("lru: capacity must be positive")
+	// ErrInvalidOption is returned for an option with an invalid value
+	ErrInvalidOption = errors.New("lru: invalid option")
+	// ErrUnsupportedOption is returned for an option the implementation cannot honour
+	ErrUnsupportedOption = errors.New("lru: unsupported option")
+)
+
+// Unbounded capacity of a cache that never evicts to make room, set with WithUnbounded
+const Unbounded = math.MaxInt
+
+// Option configures a cache built by NewWithOptions, NewSyncMapWithOptions or
+// NewShardedWithOptions. When an option is given more than once the last one wins.
+type Option func(*options)
+
+// optionKind identifies an option so each constructor can reject the ones it does not support
+type optionKind int
+
+const (
+	optionUnbounded optionKind = 1 << iota
+	optionTTL
+	optionEvict
+	optionStats
+	optionClock
+	optionWeigher
+	optionShards
+)
+
+// String returns the name of the option function
+func (k optionKind) String() string {
+	switch k {
+	case optionUnbounded:
+		return "WithUnbounded"
+	case optionTTL:
+		return "WithTTL"
+	case optionEvict:
+		return "WithEvict"
+	case optionStats:
+		return "WithStats"
+	case optionClock:
+		return "WithClock"
+	case optionWeigher:
+		return "WithWeigher"
+	case optionShards:
+		return "WithShards"
+	default:
+		return "unknown"
+	}
+}
+
+// options collected configuration, validated once every option has been applied
+type options struct {
+	set         optionKind // options given, capacity is tracked by capacitySet
+	capacity    int
+	capacitySet bool
+	ttl         time.Duration
+	onEvict     EvictCallback[any, any]
+	noStats     bool
+	now         func() time.Time
+	maxCost     int64
+	weigher     Weigher[any, any]
+	shards      int
+}
+
+// WithCapacity limits the cache to capacity entries, it must be at least 1
+func WithCapacity(capacity int) Option {
+	return func(o *options) {
+		o.capacity = capacity
+		o.capacitySet = true
+		o.set &^= optionUnbounded
+	}
+}
+
+// WithUnbounded lets the cache grow without limit, Cap then reports Unbounded.
+// Entries only leave through Remove, Clear, Resize, expiration or the cost budget.
+func WithUnbounded() Option {
+	return func(o *options) {
+		o.capacity = Unbounded
+		o.capacitySet = true
+		o.set |= optionUnbounded
+	}
+}
+
+// WithTTL makes entries expire ttl after they were put and starts a janitor that
+// sweeps them every ttl, call Close to stop it. Not supported by NewSyncMapWithOptions.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.ttl = ttl
+		o.set |= optionTTL
+	}
+}
+
+// WithEvict reports every entry that leaves the cache to onEvict
+func WithEvict(onEvict EvictCallback[any, any]) Option {
+	return func(o *options) {
+		o.onEvict = onEvict
+		o.set |= optionEvict
+	}
+}
+
+// WithStats turns the statistics on or off, they are on by default.
+// A cache without statistics skips the atomic counters and Stats reports zeros.
+func WithStats(enabled bool) Option {
+	return func(o *options) {
+		o.noStats = !enabled
+		o.set |= optionStats
+	}
+}
+
+// WithClock replaces time.Now as the source of time for expiration, mostly for tests.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+		o.set |= optionClock
+	}
+}
+
+// WithWeigher also bounds the cache by the total cost of its entries as computed by
+// weigher, see NewWithWeigher. Not supported by NewSyncMapWithOptions.
+func WithWeigher(maxCost int64, weigher Weigher[any, any]) Option {
+	return func(o *options) {
+		o.maxCost = maxCost
+		o.weigher = weigher
+		o.set |= optionWeigher
+	}
+}
+
+// WithShards sets the number of shards. Only supported by NewShardedWithOptions,
+// which uses GOMAXPROCS shards without it.
+func WithShards(shards int) Option {
+	return func(o *options) {
+		o.shards = shards
+		o.set |= optionShards
+	}
+}
+
+// newOptions applies opts and validates the result for an implementation that
+// supports the given options
+func newOptions(opts []Option, name string, supported optionKind) (*options, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if unsupported := o.set &^ supported; unsupported != 0 {
+		// Report the first unsupported option
+		kind := unsupported & -unsupported
+		return nil, fmt.Errorf("%w: %v is not supported by %s", ErrUnsupportedOption, kind, name)
+	}
+	if !o.capacitySet {
+		return nil, fmt.Errorf("%w: use WithCapacity or WithUnbounded", ErrInvalidCapacity)
+	}
+	if o.capacity < 1 {
+		return nil, fmt.Errorf("%w, got %d", ErrInvalidCapacity, o.capacity)
+	}
+	switch {
+	case o.ttl < 0:
+		return nil, fmt.Errorf("%w: negative TTL %v", ErrInvalidOption, o.ttl)
+	case o.set&optionEvict != 0 && o.onEvict == nil:
+		return nil, fmt.Errorf("%w: nil eviction callback", ErrInvalidOption)
+	case o.set&optionClock != 0 && o.now == nil:
+		return nil, fmt.Errorf("%w: nil clock", ErrInvalidOption)
+	case o.set&optionWeigher != 0 && o.maxCost < 1:
+		return nil, fmt.Errorf("%w: cost budget must be positive, got %d", ErrInvalidOption, o.maxCost)
+	case o.set&optionShards != 0 && o.shards < 1:
+		return nil, fmt.Errorf("%w: shard count must be positive, got %d", ErrInvalidOption, o.shards)
+	}
+	return o, nil
+}
+
+// NewWithOptions creates a new LRU cache. Unlike New it rejects a missing or invalid
+// capacity with ErrInvalidCapacity. It supports every option except WithShards.
+func NewWithOptions(opts ...Option) (*Cache, error) {
+	o, err := newOptions(opts, "Cache",
+		optionUnbounded|optionTTL|optionEvict|optionStats|optionClock|optionWeigher)
+	if err != nil {
+		return nil, err
+	}
+
+	c := New(o.capacity)
+	c.onEvict = o.onEvict
+	c.stats.disabled = o.noStats
+	if o.now != nil {
+		c.now = o.now
+	}
+	c.maxCost = o.maxCost
+	c.weigher = o.weigher
+	c.setTTL(o.ttl)
+	return c, nil
+}
+
+// NewSyncMapWithOptions creates a new LRU cache using sync.Map. It supports
+// WithCapacity, WithUnbounded, WithEvict, WithStats and WithClock.
+func NewSyncMapWithOptions(opts ...Option) (*SyncMapCache, error) {
+	o, err := newOptions(opts, "SyncMapCache", optionUnbounded|optionEvict|optionStats|optionClock)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewSyncMap(o.capacity)
+	c.onEvict = o.onEvict
+	c.stats.disabled = o.noStats
+	if o.now != nil {
+		c.now = o.now
+	}
+	return c, nil
+}
+
+// NewShardedWithOptions creates a new sharded LRU cache, see NewSharded. It supports
+// every option; the callback is shared by all shards, and the cost budget is divided
+// between them like the capacity. Each shard with a TTL runs its own janitor.
+func NewShardedWithOptions(opts ...Option) (*ShardedCache, error) {
+	o, err := newOptions(opts, "ShardedCache",
+		optionUnbounded|optionTTL|optionEvict|optionStats|optionClock|optionWeigher|optionShards)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := shardCount(o.shards, o.capacity)
+	if o.maxCost > 0 {
+		shards = shardCount(shards, int(min(o.maxCost, math.MaxInt)))
+	}
+	c := NewShardedWithHash(o.capacity, shards, nil)
+	for i, shard := range c.shards {
+		shard.onEvict = o.onEvict
+		shard.stats.disabled = o.noStats
+		if o.now != nil {
+			shard.now = o.now
+		}
+		if o.maxCost > 0 {
+			shard.maxCost = share(o.maxCost, len(c.shards), i)
+		}
+		shard.weigher = o.weigher
+		shard.setTTL(o.ttl)
+	}
+	return c, nil
+}
@@ -0,0 +1,325 @@
+package lru
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	cache, err := NewWithOptions(WithCapacity(2))
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	if cache.Cap() != 2 {
+		t.Errorf("Expected capacity 2, got %d", cache.Cap())
+	}
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	if !slices.Equal(cache.Keys(), []any{3, 2}) {
+		t.Errorf("Expected keys [3 2], got %v", cache.Keys())
+	}
+}
+
+func TestNewWithOptionsInvalidCapacity(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"missing", nil},
+		{"zero", []Option{WithCapacity(0)}},
+		{"negative", []Option{WithCapacity(-1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, err := NewWithOptions(tt.opts...)
+			if !errors.Is(err, ErrInvalidCapacity) {
+				t.Errorf("Expected ErrInvalidCapacity, got %v", err)
+			}
+			if cache != nil {
+				t.Error("No cache should be returned with an error")
+			}
+		})
+	}
+}
+
+func TestNewWithOptionsUnbounded(t *testing.T) {
+	cache, err := NewWithOptions(WithUnbounded())
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	if cache.Cap() != Unbounded {
+		t.Errorf("Expected capacity Unbounded, got %d", cache.Cap())
+	}
+
+	for i := 0; i < 10000; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() != 10000 || cache.Stats().Evictions != 0 {
+		t.Errorf("An unbounded cache should keep every entry, got %d with %d evictions",
+			cache.Len(), cache.Stats().Evictions)
+	}
+
+	// Resize can still bound it later
+	if n, err := cache.Resize(10); err != nil || n != 9990 {
+		t.Errorf("Expected 9990 evictions, got %d, %v", n, err)
+	}
+}
+
+func TestNewWithOptionsLastCapacityWins(t *testing.T) {
+	cache, err := NewWithOptions(WithUnbounded(), WithCapacity(3))
+	if err != nil || cache.Cap() != 3 {
+		t.Errorf("Expected the last option to set capacity 3, got %v, %v", cache, err)
+	}
+
+	if _, err := NewWithOptions(WithCapacity(3), WithCapacity(0)); !errors.Is(err, ErrInvalidCapacity) {
+		t.Errorf("A later invalid capacity should be rejected, got %v", err)
+	}
+}
+
+// New does not validate, pin what a capacity below 1 does
+func TestNewCapacityOne(t *testing.T) {
+	cache := New(1)
+
+	cache.Put(1, 1)
+	cache.Put(1, "updated") // an update does not evict
+	if value, _ := cache.Get(1); value != "updated" || cache.Len() != 1 {
+		t.Errorf("Expected the updated value in a single entry, got %v with length %d", value, cache.Len())
+	}
+
+	cache.Put(2, 2)
+	if cache.Contains(1) || !cache.Contains(2) {
+		t.Errorf("Expected 2 to replace 1, got %v", cache.Keys())
+	}
+}
+
+func TestNewWithOptionsAll(t *testing.T) {
+	clock := newFakeClock()
+	var evicted []any
+	cache, err := NewWithOptions(
+		WithUnbounded(),
+		WithTTL(time.Minute),
+		WithEvict(func(key, value any, reason EvictReason) { evicted = append(evicted, key) }),
+		WithClock(clock.Now),
+		WithWeigher(10, func(key, value any) int64 { return int64(value.(int)) }),
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	defer cache.Close()
+
+	cache.Put("a", 6)
+	cache.Put("b", 6) // over the cost budget, a goes
+	if !slices.Equal(evicted, []any{"a"}) {
+		t.Errorf("Expected a to be evicted for cost, got %v", evicted)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b should have expired on the injected clock")
+	}
+}
+
+func TestNewWithOptionsStatsDisabled(t *testing.T) {
+	cache, err := NewWithOptions(WithCapacity(1), WithStats(false))
+	if err != nil {
+		t.Fatalf("NewWithOptions failed: %v", err)
+	}
+	syncCache, err := NewSyncMapWithOptions(WithCapacity(1), WithStats(false))
+	if err != nil {
+		t.Fatalf("NewSyncMapWithOptions failed: %v", err)
+	}
+
+	for _, c := range []interface {
+		Put(key, value any)
+		Get(key any) (any, bool)
+		Stats() Stats
+	}{cache, syncCache} {
+		c.Put(1, 1)
+		c.Put(1, 2)
+		c.Put(2, 2)
+		c.Get(2)
+		c.Get(1)
+		if stats := c.Stats(); stats != (Stats{}) {
+			t.Errorf("Expected no statistics, got %+v", stats)
+		}
+	}
+}
+
+func TestNewSyncMapWithOptions(t *testing.T) {
+	var evicted []any
+	cache, err := NewSyncMapWithOptions(
+		WithCapacity(2),
+		WithEvict(func(key, value any, reason EvictReason) { evicted = append(evicted, key) }),
+	)
+	if err != nil {
+		t.Fatalf("NewSyncMapWithOptions failed: %v", err)
+	}
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	if !slices.Equal(evicted, []any{1}) {
+		t.Errorf("Expected 1 to be evicted, got %v", evicted)
+	}
+}
+
+func TestNewShardedWithOptions(t *testing.T) {
+	var mu sync.Mutex
+	evictions := 0
+	cache, err := NewShardedWithOptions(
+		WithCapacity(8),
+		WithShards(4),
+		WithEvict(func(key, value any, reason EvictReason) {
+			mu.Lock()
+			evictions++
+			mu.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewShardedWithOptions failed: %v", err)
+	}
+
+	if cache.Shards() != 4 || cache.Cap() != 8 {
+		t.Errorf("Expected 4 shards and capacity 8, got %d and %d", cache.Shards(), cache.Cap())
+	}
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	if evictions != 100-cache.Len() {
+		t.Errorf("Expected the callback for %d evictions, got %d", 100-cache.Len(), evictions)
+	}
+}
+
+func TestNewShardedWithOptionsHugeCapacity(t *testing.T) {
+	cache, err := NewShardedWithOptions(WithCapacity(math.MaxInt), WithShards(4))
+	if err != nil {
+		t.Fatalf("NewShardedWithOptions failed: %v", err)
+	}
+
+	if cache.Cap() != math.MaxInt {
+		t.Errorf("Expected capacity math.MaxInt, got %d", cache.Cap())
+	}
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() != 100 {
+		t.Errorf("Expected every entry to be kept, got %d", cache.Len())
+	}
+}
+
+func TestNewShardedWithOptionsAll(t *testing.T) {
+	clock := newFakeClock()
+	cache, err := NewShardedWithOptions(
+		WithUnbounded(),
+		WithShards(4),
+		WithTTL(time.Minute),
+		WithClock(clock.Now),
+		WithWeigher(10, func(key, value any) int64 { return int64(value.(int)) }),
+	)
+	if err != nil {
+		t.Fatalf("NewShardedWithOptions failed: %v", err)
+	}
+	defer cache.Close()
+
+	if cache.Cap() != Unbounded || cache.MaxCost() != 10 {
+		t.Errorf("Expected capacity Unbounded and budget 10, got %d and %d", cache.Cap(), cache.MaxCost())
+	}
+
+	// The budget of 10 is split into shards of at most 3
+	if cache.PutWithCost("big", 0, 4) {
+		t.Error("An entry costing more than the budget of its shard should be rejected")
+	}
+	cache.Put("a", 1)
+	if cache.TotalCost() != 1 {
+		t.Errorf("Expected total cost 1 from the weigher, got %d", cache.TotalCost())
+	}
+
+	clock.Advance(time.Minute)
+	if cache.Contains("a") {
+		t.Error("a should have expired")
+	}
+}
+
+func TestNewShardedWithOptionsSmallBudget(t *testing.T) {
+	cache, err := NewShardedWithOptions(WithCapacity(100), WithShards(8), WithWeigher(3, nil))
+	if err != nil {
+		t.Fatalf("NewShardedWithOptions failed: %v", err)
+	}
+
+	// Every shard gets part of the budget, none is left unbounded
+	if cache.Shards() != 3 || cache.MaxCost() != 3 {
+		t.Errorf("Expected 3 shards and budget 3, got %d and %d", cache.Shards(), cache.MaxCost())
+	}
+}
+
+func TestOptionsUnsupported(t *testing.T) {
+	noop := func(key, value any) int64 { return 1 }
+	tests := []struct {
+		name string
+		new  func() error
+	}{
+		{"shards on Cache", func() error {
+			_, err := NewWithOptions(WithCapacity(10), WithShards(2))
+			return err
+		}},
+		{"TTL on SyncMapCache", func() error {
+			_, err := NewSyncMapWithOptions(WithCapacity(10), WithTTL(time.Minute))
+			return err
+		}},
+		{"weigher on SyncMapCache", func() error {
+			_, err := NewSyncMapWithOptions(WithCapacity(10), WithWeigher(10, noop))
+			return err
+		}},
+		{"shards on SyncMapCache", func() error {
+			_, err := NewSyncMapWithOptions(WithCapacity(10), WithShards(2))
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.new(); !errors.Is(err, ErrUnsupportedOption) {
+				t.Errorf("Expected ErrUnsupportedOption, got %v", err)
+			}
+		})
+	}
+
+	// The error names the option
+	_, err := NewSyncMapWithOptions(WithCapacity(10), WithTTL(time.Minute))
+	if err == nil || !strings.Contains(err.Error(), "WithTTL") {
+		t.Errorf("Expected the error to name WithTTL, got %v", err)
+	}
+}
+
+func TestOptionsInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"negative TTL", []Option{WithTTL(-time.Second)}},
+		{"nil callback", []Option{WithEvict(nil)}},
+		{"nil clock", []Option{WithClock(nil)}},
+		{"zero cost budget", []Option{WithWeigher(0, nil)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithOptions(append(tt.opts, WithCapacity(10))...)
+			if !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("Expected ErrInvalidOption, got %v", err)
+			}
+		})
+	}
+
+	if _, err := NewShardedWithOptions(WithCapacity(10), WithShards(0)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("Expected ErrInvalidOption for zero shards, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package lru
+
+import "fmt"
+
+// Resize changes the capacity of the cache. Shrinking evicts least recently used
+// entries until the cache fits, reporting them to the eviction callback with
+// EvictReasonCapacity, and Resize returns how many were evicted. A capacity below 1
+// is rejected with an error wrapping ErrInvalidCapacity and leaves the cache unchanged.
+func (c *TypedCache[K, V]) Resize(newCap int) (int, error) {
+	if err := checkCapacity(newCap); err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	before := c.list.Len()
+	c.capacity.Store(int64(newCap))
+	for c.list.Len() > newCap {
+		c.removeOldest(EvictReasonCapacity)
+	}
+	evictedCount := before - c.list.Len()
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return evictedCount, nil
+}
+
+// Resize changes the capacity of the cache, evicting least recently used entries if
+// it shrinks, and returns how many were evicted. A capacity below 1 is rejected with
+// an error wrapping ErrInvalidCapacity and leaves the cache unchanged.
+func (c *SyncMapCache) Resize(newCap int) (int, error) {
+	if err := checkCapacity(newCap); err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	before := c.list.Len()
+	c.capacity.Store(int64(newCap))
+	for c.list.Len() > newCap {
+		c.removeOldest()
+	}
+	evictedCount := before - c.list.Len()
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return evictedCount, nil
+}
+
+// checkCapacity returns an error if a capacity is not positive
+func checkCapacity(capacity int) error {
+	if capacity < 1 {
+		return fmt.Errorf("%w, got %d", ErrInvalidCapacity, capacity)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package lru
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// resizeCache the methods Resize tests need from Cache and SyncMapCache
+type resizeCache interface {
+	Put(key, value any)
+	Keys() []any
+	Len() int
+	Cap() int
+	Stats() Stats
+	Resize(newCap int) (int, error)
+}
+
+func TestResize(t *testing.T) {
+	for name, newCache := range map[string]func(capacity int, onEvict EvictCallback[any, any]) resizeCache{
+		"RWMutex": func(capacity int, onEvict EvictCallback[any, any]) resizeCache {
+			return NewWithEvict(capacity, onEvict)
+		},
+		"SyncMap": func(capacity int, onEvict EvictCallback[any, any]) resizeCache {
+			return NewSyncMapWithEvict(capacity, onEvict)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var evicted []any
+			c := newCache(5, func(key, value any, reason EvictReason) {
+				if reason != EvictReasonCapacity {
+					t.Errorf("Expected reason capacity, got %v", reason)
+				}
+				evicted = append(evicted, key)
+			})
+			for i := 0; i < 5; i++ {
+				c.Put(i, i)
+			}
+
+			// Shrinking evicts the least recently used entries
+			if n, err := c.Resize(2); err != nil || n != 3 {
+				t.Errorf("Expected 3 evictions, got %d, %v", n, err)
+			}
+			if !slices.Equal(evicted, []any{0, 1, 2}) {
+				t.Errorf("Expected callbacks for 0, 1 and 2, got %v", evicted)
+			}
+			if c.Cap() != 2 || !slices.Equal(c.Keys(), []any{4, 3}) {
+				t.Errorf("Expected capacity 2 with keys [4 3], got %d %v", c.Cap(), c.Keys())
+			}
+			if c.Stats().Evictions != 3 {
+				t.Errorf("Expected 3 evictions in the stats, got %d", c.Stats().Evictions)
+			}
+
+			// The new capacity applies to later puts
+			c.Put(5, 5)
+			if c.Len() != 2 {
+				t.Errorf("Expected length 2, got %d", c.Len())
+			}
+
+			// Growing evicts nothing and makes room
+			if n, err := c.Resize(4); err != nil || n != 0 {
+				t.Errorf("Growing should not evict, got %d, %v", n, err)
+			}
+			c.Put(6, 6)
+			c.Put(7, 7)
+			if c.Len() != 4 {
+				t.Errorf("Expected length 4 after growing, got %d", c.Len())
+			}
+		})
+	}
+}
+
+func TestResizeInvalid(t *testing.T) {
+	for name, c := range map[string]resizeCache{"RWMutex": New(10), "SyncMap": NewSyncMap(10)} {
+		c.Put(1, 1)
+		c.Put(2, 2)
+
+		for _, capacity := range []int{0, -1} {
+			if n, err := c.Resize(capacity); !errors.Is(err, ErrInvalidCapacity) || n != 0 {
+				t.Errorf("%s: expected ErrInvalidCapacity for %d, got %d, %v", name, capacity, n, err)
+			}
+		}
+		// A rejected resize leaves the cache unchanged
+		if c.Cap() != 10 || c.Len() != 2 {
+			t.Errorf("%s: expected capacity 10 with 2 entries, got %d and %d", name, c.Cap(), c.Len())
+		}
+	}
+}
+
+func TestResizeConcurrentCap(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			cache.Put(i, i)
+			cache.Resize(50 + i%100)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if c := cache.Cap(); c < 50 || c > 149 {
+				t.Errorf("Cap returned %d during a resize", c)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
@@ -0,0 +1,215 @@
+package lru
+
+import (
+	"hash/maphash"
+	"runtime"
+	"time"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// HashFunc maps a key to the shard that stores it
+type HashFunc func(key any) uint64
+
+// ShardedCache LRU cache split into independently locked shards.
+// Each shard evicts on its own, so recency is only tracked per shard.
+//
+// It provides the methods of Cache that act on a single key, including PutWithTTL,
+// PutWithCost and GetOrLoad, plus Close and the aggregate Stats and costs. The
+// iterators, the batch methods, Save, Load and Resize are not provided: they need a
+// consistent view of the whole cache that independently locked shards cannot give.
+type ShardedCache struct {
+	shards []*Cache
+	hash   HashFunc
+}
+
+var _ cache.Cache = (*ShardedCache)(nil)
+
+// NewSharded creates a new LRU cache spread over the given number of shards.
+// The capacity is divided between the shards so that Cap reports exactly capacity;
+// there are never more shards than entries, so Shards may be fewer than requested.
+// A non-positive shard count uses GOMAXPROCS.
+func NewSharded(capacity, shards int) *ShardedCache {
+	return NewShardedWithHash(capacity, shards, nil)
+}
+
+// NewShardedWithHash creates a new sharded LRU cache that places keys with hash,
+// a nil hash uses hash/maphash
+func NewShardedWithHash(capacity, shards int, hash HashFunc) *ShardedCache {
+	shards = shardCount(shards, capacity)
+	if hash == nil {
+		seed := maphash.MakeSeed()
+		hash = func(key any) uint64 {
+			return maphash.Comparable(seed, key)
+		}
+	}
+
+	c := &ShardedCache{
+		shards: make([]*Cache, shards),
+		hash:   hash,
+	}
+	for i := range c.shards {
+		c.shards[i] = New(int(share(int64(capacity), shards, i)))
+	}
+	return c
+}
+
+// shardCount returns the number of shards to use, at most limit so that every
+// shard gets a share of it. A capacity below 1 gets a single shard.
+func shardCount(shards, limit int) int {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	return max(min(shards, limit), 1)
+}
+
+// share returns the part of total given to shard i of n. The remainder is spread
+// over the first shards, so the parts add up to total without overflowing.
+func share(total int64, n, i int) int64 {
+	part := total / int64(n)
+	if int64(i) < total%int64(n) {
+		part++
+	}
+	return part
+}
+
+// shard returns the shard responsible for key
+func (c *ShardedCache) shard(key any) *Cache {
+	return c.shards[c.hash(key)%uint64(len(c.shards))]
+}
+
+// Get retrieves a value from the cache
+func (c *ShardedCache) Get(key any) (any, bool) {
+	return c.shard(key).Get(key)
+}
+
+// Put adds a key-value pair to the cache
+func (c *ShardedCache) Put(key, value any) {
+	c.shard(key).Put(key, value)
+}
+
+// PutWithTTL adds a key-value pair that expires after ttl, a non-positive ttl means it never expires
+func (c *ShardedCache) PutWithTTL(key, value any, ttl time.Duration) {
+	c.shard(key).PutWithTTL(key, value, ttl)
+}
+
+// PutWithCost adds a key-value pair with an explicit cost, see Cache.PutWithCost.
+// The entry must fit in the budget of its shard.
+func (c *ShardedCache) PutWithCost(key, value any, cost int64) bool {
+	return c.shard(key).PutWithCost(key, value, cost)
+}
+
+// GetOrLoad returns the value for key, loading it on a miss, see Cache.GetOrLoad
+func (c *ShardedCache) GetOrLoad(key any, loader func() (any, error)) (any, error) {
+	return c.shard(key).GetOrLoad(key, loader)
+}
+
+// SetNegativeTTL makes GetOrLoad remember loader errors for ttl in every shard
+func (c *ShardedCache) SetNegativeTTL(ttl time.Duration) {
+	for _, shard := range c.shards {
+		shard.SetNegativeTTL(ttl)
+	}
+}
+
+// Remove removes a key from the cache
+func (c *ShardedCache) Remove(key any) bool {
+	return c.shard(key).Remove(key)
+}
+
+// Peek looks up a value without updating the access order
+func (c *ShardedCache) Peek(key any) (any, bool) {
+	return c.shard(key).Peek(key)
+}
+
+// Contains checks if the cache contains a specific key
+func (c *ShardedCache) Contains(key any) bool {
+	return c.shard(key).Contains(key)
+}
+
+// Keys returns all keys in the cache, shard by shard.
+// Keys are in access order within a shard but not across shards.
+func (c *ShardedCache) Keys() []any {
+	keys := make([]any, 0, c.Len())
+	for _, shard := range c.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Len returns the number of elements in the cache
+func (c *ShardedCache) Len() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Cap returns the capacity of the cache
+func (c *ShardedCache) Cap() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Cap()
+	}
+	return n
+}
+
+// TotalCost returns the total cost of the entries in the cache
+func (c *ShardedCache) TotalCost() int64 {
+	var n int64
+	for _, shard := range c.shards {
+		n += shard.TotalCost()
+	}
+	return n
+}
+
+// MaxCost returns the cost budget of the cache, split between the shards,
+// zero if it is only bounded by capacity
+func (c *ShardedCache) MaxCost() int64 {
+	var n int64
+	for _, shard := range c.shards {
+		n += shard.MaxCost()
+	}
+	return n
+}
+
+// Close stops the background janitors of the shards, it is safe to call more than once
+func (c *ShardedCache) Close() {
+	for _, shard := range c.shards {
+		shard.Close()
+	}
+}
+
+// Clear removes all elements from the cache
+func (c *ShardedCache) Clear() {
+	for _, shard := range c.shards {
+		shard.Clear()
+	}
+}
+
+// Shards returns the number of shards
+func (c *ShardedCache) Shards() int {
+	return len(c.shards)
+}
+
+// Stats returns the statistics summed over all shards
+func (c *ShardedCache) Stats() Stats {
+	var total Stats
+	for _, shard := range c.shards {
+		stats := shard.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Puts += stats.Puts
+		total.Updates += stats.Updates
+		total.Evictions += stats.Evictions
+		total.Removals += stats.Removals
+	}
+	return total
+}
+
+// ResetStats sets every statistic back to zero
+func (c *ShardedCache) ResetStats() {
+	for _, shard := range c.shards {
+		shard.ResetStats()
+	}
+}
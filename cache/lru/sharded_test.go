@@ -0,0 +1,218 @@
+package lru
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedCache(t *testing.T) {
+	cache := NewSharded(100, 4)
+
+	for i := 0; i < 50; i++ {
+		cache.Put(i, i*10)
+	}
+
+	for i := 0; i < 50; i++ {
+		if value, ok := cache.Get(i); !ok || value != i*10 {
+			t.Errorf("Expected %d for key %d, got %v", i*10, i, value)
+		}
+	}
+
+	if cache.Len() != 50 {
+		t.Errorf("Expected length 50, got %d", cache.Len())
+	}
+	if len(cache.Keys()) != 50 {
+		t.Errorf("Expected 50 keys, got %d", len(cache.Keys()))
+	}
+
+	if !cache.Remove(7) || cache.Contains(7) {
+		t.Error("key 7 should have been removed")
+	}
+	if value, ok := cache.Peek(8); !ok || value != 80 {
+		t.Errorf("Expected Peek to return 80, got %v", value)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+}
+
+func TestShardedCacheCapacity(t *testing.T) {
+	cache := NewSharded(10, 4)
+
+	if cache.Shards() != 4 {
+		t.Errorf("Expected 4 shards, got %d", cache.Shards())
+	}
+	// 10 split over 4 shards gives two shards 3 entries and two shards 2
+	if cache.Cap() != 10 {
+		t.Errorf("Expected capacity 10, got %d", cache.Cap())
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestShardedCacheMoreShardsThanCapacity(t *testing.T) {
+	cache := NewSharded(10, 16)
+
+	// Every shard keeps at least one entry, so there are no more shards than entries
+	if cache.Shards() != 10 || cache.Cap() != 10 {
+		t.Errorf("Expected 10 shards and capacity 10, got %d and %d", cache.Shards(), cache.Cap())
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+	if cache.Len() > 10 {
+		t.Errorf("Cache length %d exceeds capacity 10", cache.Len())
+	}
+
+	// A capacity below 1 keeps the legacy behaviour of New in a single shard
+	if small := NewSharded(0, 4); small.Shards() != 1 || small.Cap() != 0 {
+		t.Errorf("Expected 1 shard and capacity 0, got %d and %d", small.Shards(), small.Cap())
+	}
+}
+
+func TestShardedCacheTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewSharded(100, 4)
+	for _, shard := range cache.shards {
+		shard.now = clock.Now
+	}
+
+	cache.PutWithTTL("short", 1, time.Second)
+	cache.PutWithTTL("long", 2, time.Hour)
+	clock.Advance(time.Minute)
+
+	if cache.Contains("short") {
+		t.Error("short should have expired")
+	}
+	if value, ok := cache.Get("long"); !ok || value != 2 {
+		t.Errorf("Expected long to be 2, got %v", value)
+	}
+	cache.Close()
+}
+
+func TestShardedCacheCost(t *testing.T) {
+	cache := NewSharded(100, 2)
+	for i, shard := range cache.shards {
+		shard.maxCost = share(10, len(cache.shards), i)
+	}
+
+	if cache.MaxCost() != 10 {
+		t.Errorf("Expected a budget of 10, got %d", cache.MaxCost())
+	}
+	if !cache.PutWithCost("a", 1, 3) || cache.TotalCost() != 3 {
+		t.Errorf("Expected a total cost of 3, got %d", cache.TotalCost())
+	}
+	// Each shard has half of the budget
+	if cache.PutWithCost("b", 2, 6) || cache.Contains("b") {
+		t.Error("An entry costing more than the budget of its shard should be rejected")
+	}
+}
+
+func TestShardedCacheGetOrLoad(t *testing.T) {
+	t.Run("Load", func(t *testing.T) { testGetOrLoad(t, NewSharded(10, 4)) })
+	t.Run("Deduplicates", func(t *testing.T) { testGetOrLoadDeduplicates(t, NewSharded(10, 4)) })
+	t.Run("Error", func(t *testing.T) { testGetOrLoadError(t, NewSharded(10, 4)) })
+	t.Run("Panic", func(t *testing.T) { testGetOrLoadPanic(t, NewSharded(10, 4)) })
+}
+
+func TestShardedCacheDefaultShards(t *testing.T) {
+	cache := NewSharded(100, 0)
+
+	if cache.Shards() != runtime.GOMAXPROCS(0) {
+		t.Errorf("Expected %d shards, got %d", runtime.GOMAXPROCS(0), cache.Shards())
+	}
+}
+
+func TestShardedCacheCustomHash(t *testing.T) {
+	// Even keys go to shard 0, odd keys to shard 1
+	cache := NewShardedWithHash(4, 2, func(key any) uint64 {
+		return uint64(key.(int))
+	})
+
+	cache.Put(0, "zero")
+	cache.Put(2, "two")
+	cache.Put(1, "one")
+	cache.Get(0)
+
+	// Shard 0 holds two entries, 2 is its least recently used one
+	cache.Put(4, "four")
+
+	if cache.Contains(2) {
+		t.Error("key 2 should have been evicted from its shard")
+	}
+	for _, key := range []int{0, 1, 4} {
+		if !cache.Contains(key) {
+			t.Errorf("key %d should still be in cache", key)
+		}
+	}
+}
+
+func TestShardedCacheStats(t *testing.T) {
+	cache := NewSharded(100, 4)
+
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < 20; i++ {
+		cache.Get(i)
+	}
+
+	stats := cache.Stats()
+	if stats.Puts != 10 || stats.Hits != 10 || stats.Misses != 10 {
+		t.Errorf("Expected 10 puts, 10 hits and 10 misses, got %+v", stats)
+	}
+
+	cache.ResetStats()
+	if stats := cache.Stats(); stats != (Stats{}) {
+		t.Errorf("Expected zero stats after reset, got %+v", stats)
+	}
+}
+
+func TestShardedCacheConcurrency(t *testing.T) {
+	cache := NewSharded(100, 8)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := id*100 + j
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
@@ -0,0 +1,250 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// DefaultProtectedRatio default share of an SLRU cache's capacity given to the protected segment
+const DefaultProtectedRatio = 0.8
+
+// Segment identifies a segment of an SLRU cache
+type Segment int
+
+const (
+	// SegmentProbation new keys and keys demoted from protected
+	SegmentProbation Segment = iota
+	// SegmentProtected keys hit at least once after entering probation
+	SegmentProtected
+)
+
+// String returns the name of the segment
+func (s Segment) String() string {
+	switch s {
+	case SegmentProbation:
+		return "probation"
+	case SegmentProtected:
+		return "protected"
+	default:
+		return "unknown"
+	}
+}
+
+// SLRUCache segmented LRU cache structure.
+//
+// New keys enter the probation segment. A key hit again is promoted to the protected
+// segment, which holds at most protectedCap keys; when it overflows, its least
+// recently used key is demoted to the head of probation. Evictions take the least
+// recently used key of probation, so keys used only once never push out keys used
+// repeatedly.
+type SLRUCache struct {
+	capacity     int
+	protectedCap int
+	cache        map[any]*list.Element
+	probation    *list.List // most recently used first
+	protected    *list.List // most recently used first
+	mutex        sync.RWMutex
+}
+
+// slruEntry cache entry for SLRUCache
+type slruEntry struct {
+	key     any
+	value   any
+	segment Segment
+}
+
+var _ cache.Cache = (*SLRUCache)(nil)
+
+// NewSLRU creates a new SLRU cache with the default protected ratio
+func NewSLRU(capacity int) *SLRUCache {
+	return NewSLRUWithRatio(capacity, DefaultProtectedRatio)
+}
+
+// NewSLRUWithRatio creates a new SLRU cache giving protectedRatio of the capacity to
+// the protected segment. The ratio must be within [0, 1], otherwise the default is used.
+func NewSLRUWithRatio(capacity int, protectedRatio float64) *SLRUCache {
+	if protectedRatio < 0 || protectedRatio > 1 {
+		protectedRatio = DefaultProtectedRatio
+	}
+	return &SLRUCache{
+		capacity:     capacity,
+		protectedCap: int(float64(capacity) * protectedRatio),
+		cache:        make(map[any]*list.Element),
+		probation:    list.New(),
+		protected:    list.New(),
+	}
+}
+
+// Get retrieves a value from the cache, promoting a probation key to protected
+func (c *SLRUCache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		c.touch(element)
+		return element.Value.(*slruEntry).value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache, updating an existing key counts as a hit
+func (c *SLRUCache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		element.Value.(*slruEntry).value = value
+		c.touch(element)
+		return
+	}
+
+	// If the cache is full, remove the least recently used key of probation
+	if len(c.cache) >= c.capacity {
+		c.removeOldest()
+	}
+
+	e := &slruEntry{key: key, value: value, segment: SegmentProbation}
+	c.cache[key] = c.probation.PushFront(e)
+}
+
+// touch records a hit, moving the key to the front of protected
+func (c *SLRUCache) touch(element *list.Element) {
+	e := element.Value.(*slruEntry)
+	if e.segment == SegmentProtected {
+		c.protected.MoveToFront(element)
+		return
+	}
+
+	c.probation.Remove(element)
+	e.segment = SegmentProtected
+	c.cache[e.key] = c.protected.PushFront(e)
+
+	// Demote the least recently used protected key to the head of probation
+	if c.protected.Len() > c.protectedCap {
+		oldest := c.protected.Back()
+		demoted := oldest.Value.(*slruEntry)
+		c.protected.Remove(oldest)
+		demoted.segment = SegmentProbation
+		c.cache[demoted.key] = c.probation.PushFront(demoted)
+	}
+}
+
+// removeOldest removes the least recently used key of probation, or of protected
+// if probation is empty
+func (c *SLRUCache) removeOldest() {
+	oldest := c.probation.Back()
+	if oldest == nil {
+		oldest = c.protected.Back()
+	}
+	if oldest != nil {
+		c.removeElement(oldest)
+	}
+}
+
+// removeElement removes a specific element
+func (c *SLRUCache) removeElement(element *list.Element) {
+	e := element.Value.(*slruEntry)
+	if e.segment == SegmentProtected {
+		c.protected.Remove(element)
+	} else {
+		c.probation.Remove(element)
+	}
+	delete(c.cache, e.key)
+}
+
+// Remove removes a key from the cache
+func (c *SLRUCache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		c.removeElement(element)
+		return true
+	}
+	return false
+}
+
+// Len returns the number of elements in the cache
+func (c *SLRUCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.cache)
+}
+
+// Cap returns the capacity of the cache
+func (c *SLRUCache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// ProtectedCap returns the maximum number of keys in the protected segment
+func (c *SLRUCache) ProtectedCap() int {
+	return c.protectedCap
+}
+
+// Clear removes all elements from the cache
+func (c *SLRUCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cache = make(map[any]*list.Element)
+	c.probation.Init()
+	c.protected.Init()
+}
+
+// Keys returns all keys in the cache, the protected segment first and then
+// probation, each most recently used first. Use SegmentKeys or Segment to tell
+// the segments apart.
+func (c *SLRUCache) Keys() []any {
+	protected, probation := c.SegmentKeys()
+	return append(protected, probation...)
+}
+
+// SegmentKeys returns the keys of each segment, most recently used first
+func (c *SLRUCache) SegmentKeys() (protected, probation []any) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	protected = make([]any, 0, len(c.cache))
+	for element := c.protected.Front(); element != nil; element = element.Next() {
+		protected = append(protected, element.Value.(*slruEntry).key)
+	}
+	probation = make([]any, 0, c.probation.Len())
+	for element := c.probation.Front(); element != nil; element = element.Next() {
+		probation = append(probation, element.Value.(*slruEntry).key)
+	}
+	return protected, probation
+}
+
+// Segment reports which segment holds a key
+func (c *SLRUCache) Segment(key any) (Segment, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*slruEntry).segment, true
+	}
+	return SegmentProbation, false
+}
+
+// Contains checks if the cache contains a specific key
+func (c *SLRUCache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without updating the access order or promoting it
+func (c *SLRUCache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*slruEntry).value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,264 @@
+package lru
+
+import (
+	"sync"
+	"testing"
+)
+
+// checkSegments compares both segments, most recently used first, with the expected keys
+func checkSegments(t *testing.T, c *SLRUCache, protected, probation []any) {
+	t.Helper()
+
+	gotProtected, gotProbation := c.SegmentKeys()
+	if !equalKeys(gotProtected, protected) || !equalKeys(gotProbation, probation) {
+		t.Fatalf("Expected protected %v and probation %v, got %v and %v",
+			protected, probation, gotProtected, gotProbation)
+	}
+}
+
+func equalKeys(a, b []any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSLRUCache(t *testing.T) {
+	cache := NewSLRU(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, key2 is the only key left in probation
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestSLRUCacheSegments(t *testing.T) {
+	cache := NewSLRUWithRatio(4, 0.5) // protected holds 2
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	checkSegments(t, cache, []any{}, []any{3, 2, 1})
+
+	// A second hit promotes to protected
+	cache.Get(1)
+	cache.Get(2)
+	checkSegments(t, cache, []any{2, 1}, []any{3})
+
+	if segment, ok := cache.Segment(1); !ok || segment != SegmentProtected {
+		t.Errorf("Expected key 1 in protected, got %v", segment)
+	}
+	if segment, ok := cache.Segment(3); !ok || segment != SegmentProbation {
+		t.Errorf("Expected key 3 in probation, got %v", segment)
+	}
+	if _, ok := cache.Segment("missing"); ok {
+		t.Error("Segment should report false for a missing key")
+	}
+
+	// Protected is full, promoting 3 demotes 1 to the head of probation
+	cache.Get(3)
+	checkSegments(t, cache, []any{3, 2}, []any{1})
+
+	// Keys reports protected first
+	keys := cache.Keys()
+	expected := []any{3, 2, 1}
+	if !equalKeys(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+}
+
+func TestSLRUCacheEviction(t *testing.T) {
+	cache := NewSLRUWithRatio(3, 0.5) // protected holds 1
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Get(1)
+	cache.Put(3, 3)
+	checkSegments(t, cache, []any{1}, []any{3, 2})
+
+	// Evictions come from the back of probation
+	cache.Put(4, 4)
+	if cache.Contains(2) {
+		t.Error("key 2 should have been evicted")
+	}
+	checkSegments(t, cache, []any{1}, []any{4, 3})
+
+	// Demoted keys go to the head of probation and outlive older probation keys
+	cache.Get(3)
+	checkSegments(t, cache, []any{3}, []any{1, 4})
+	cache.Put(5, 5)
+	if cache.Contains(4) {
+		t.Error("key 4 should have been evicted")
+	}
+	checkSegments(t, cache, []any{3}, []any{5, 1})
+}
+
+func TestSLRUCacheScanResistance(t *testing.T) {
+	cache := NewSLRU(10)
+
+	// A working set used twice ends up in protected
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+		cache.Get(i)
+	}
+
+	// A one-off scan only churns probation
+	for i := 100; i < 200; i++ {
+		cache.Put(i, i)
+	}
+
+	for i := 0; i < 5; i++ {
+		if !cache.Contains(i) {
+			t.Errorf("Working set key %d should survive the scan", i)
+		}
+	}
+}
+
+func TestSLRUCacheRatio(t *testing.T) {
+	if c := NewSLRU(10); c.ProtectedCap() != 8 {
+		t.Errorf("Expected default protected capacity 8, got %d", c.ProtectedCap())
+	}
+	if c := NewSLRUWithRatio(10, 0.3); c.ProtectedCap() != 3 {
+		t.Errorf("Expected protected capacity 3, got %d", c.ProtectedCap())
+	}
+	if c := NewSLRUWithRatio(10, 1.5); c.ProtectedCap() != 8 {
+		t.Errorf("Expected an invalid ratio to fall back to the default, got %d", c.ProtectedCap())
+	}
+
+	// Without a protected segment the cache behaves like LRU
+	cache := NewSLRUWithRatio(2, 0)
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Get(1)
+	cache.Put(3, 3)
+	checkSegments(t, cache, []any{}, []any{3, 1})
+}
+
+func TestSLRUCacheUpdate(t *testing.T) {
+	cache := NewSLRU(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Peek("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Updating counts as a hit
+	if segment, _ := cache.Segment("key1"); segment != SegmentProtected {
+		t.Errorf("Expected an updated key in protected, got %v", segment)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestSLRUCachePeekRemoveClear(t *testing.T) {
+	cache := NewSLRU(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("b")
+
+	// Peek should not promote
+	cache.Peek("a")
+	if segment, _ := cache.Segment("a"); segment != SegmentProbation {
+		t.Errorf("Peek should not promote, got %v", segment)
+	}
+
+	if !cache.Remove("b") || cache.Remove("b") {
+		t.Error("Remove should report true once for an existing key")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 || len(cache.Keys()) != 0 {
+		t.Error("Cache should be empty after clear")
+	}
+}
+
+func TestSegmentString(t *testing.T) {
+	if SegmentProbation.String() != "probation" || SegmentProtected.String() != "protected" {
+		t.Error("Unexpected segment names")
+	}
+	if Segment(42).String() != "unknown" {
+		t.Error("Expected unknown for an invalid segment")
+	}
+}
+
+func TestSLRUCacheConcurrency(t *testing.T) {
+	cache := NewSLRU(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Segment(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Keys()
+			cache.SegmentKeys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
+
+func BenchmarkSLRUCacheMixed(b *testing.B) {
+	cache := NewSLRU(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
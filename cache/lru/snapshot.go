@@ -0,0 +1,130 @@
+package lru
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// Encoder writes values to a snapshot stream
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder reads values from a snapshot stream, returning io.EOF at the end
+type Decoder interface {
+	Decode(v any) error
+}
+
+// Codec serializes cache snapshots
+type Codec interface {
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+var (
+	// GobCodec encodes snapshots with encoding/gob. Concrete types stored in
+	// interface-typed keys or values must be registered with gob.Register.
+	GobCodec Codec = gobCodec{}
+	// JSONCodec encodes snapshots with encoding/json. Interface-typed keys and values
+	// come back as the generic JSON types, for example numbers as float64.
+	JSONCodec Codec = jsonCodec{}
+)
+
+type gobCodec struct{}
+
+func (gobCodec) NewEncoder(w io.Writer) Encoder { return gob.NewEncoder(w) }
+func (gobCodec) NewDecoder(r io.Reader) Decoder { return gob.NewDecoder(r) }
+
+type jsonCodec struct{}
+
+func (jsonCodec) NewEncoder(w io.Writer) Encoder { return json.NewEncoder(w) }
+func (jsonCodec) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+// snapshotEntry serialized form of a cache entry
+type snapshotEntry[K comparable, V any] struct {
+	Key       K         `json:"key"`
+	Value     V         `json:"value"`
+	Cost      int64     `json:"cost"`
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+}
+
+// Save writes the cache entries to w with GobCodec
+func (c *TypedCache[K, V]) Save(w io.Writer) error {
+	return c.SaveWith(w, GobCodec)
+}
+
+// SaveWith writes the cache entries to w with codec, most recently used first,
+// the same order Keys returns. Expired entries are skipped.
+func (c *TypedCache[K, V]) SaveWith(w io.Writer, codec Codec) error {
+	// Copy the entries under the lock and encode without it
+	c.mutex.RLock()
+	now := c.now()
+	entries := make([]snapshotEntry[K, V], 0, c.list.Len())
+	for element := c.list.Front(); element != nil; element = element.Next() {
+		e := element.Value.(*entry[K, V])
+		if e.expired(now) {
+			continue
+		}
+		entries = append(entries, snapshotEntry[K, V]{Key: e.key, Value: e.value, Cost: e.cost, ExpiresAt: e.expiresAt})
+	}
+	c.mutex.RUnlock()
+
+	encoder := codec.NewEncoder(w)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load reads entries written by Save from r
+func (c *TypedCache[K, V]) Load(r io.Reader) error {
+	return c.LoadWith(r, GobCodec)
+}
+
+// LoadWith reads entries written by SaveWith from r with codec and puts them so the
+// cache ends up in the same recency order. Loaded entries become more recent than the
+// existing ones. If the cache is smaller than the snapshot, only the most recent entries
+// are kept. Nothing is loaded if the snapshot cannot be decoded.
+func (c *TypedCache[K, V]) LoadWith(r io.Reader, codec Codec) error {
+	decoder := codec.NewDecoder(r)
+	var entries []snapshotEntry[K, V]
+	for {
+		var e snapshotEntry[K, V]
+		err := decoder.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		entries = append(entries, e)
+	}
+
+	c.mutex.Lock()
+	now := c.now()
+	live := entries[:0]
+	for _, e := range entries {
+		if e.ExpiresAt.IsZero() || now.Before(e.ExpiresAt) {
+			live = append(live, e)
+		}
+	}
+	// A capacity below 1 still keeps one entry, as Put does
+	if limit := max(c.Cap(), 1); len(live) > limit {
+		live = live[:limit]
+	}
+	// Put the least recent entry first so the most recent one ends up at the front
+	for i := len(live) - 1; i >= 0; i-- {
+		e := live[i]
+		c.put(e.Key, e.Value, e.Cost, e.ExpiresAt)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return nil
+}
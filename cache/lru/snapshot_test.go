@@ -0,0 +1,213 @@
+package lru
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheSaveLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		codec Codec
+	}{
+		{"gob", GobCodec},
+		{"json", JSONCodec},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			source := NewTyped[string, int](5)
+			source.Put("a", 1)
+			source.Put("b", 2)
+			source.Put("c", 3)
+			source.Get("a")
+
+			var buf bytes.Buffer
+			if err := source.SaveWith(&buf, tc.codec); err != nil {
+				t.Fatalf("SaveWith failed: %v", err)
+			}
+
+			restored := NewTyped[string, int](5)
+			if err := restored.LoadWith(&buf, tc.codec); err != nil {
+				t.Fatalf("LoadWith failed: %v", err)
+			}
+
+			expected := source.Keys()
+			keys := restored.Keys()
+			if len(keys) != len(expected) {
+				t.Fatalf("Expected keys %v, got %v", expected, keys)
+			}
+			for i := range expected {
+				if keys[i] != expected[i] {
+					t.Errorf("Expected keys %v in the same order, got %v", expected, keys)
+					break
+				}
+			}
+			for _, key := range expected {
+				want, _ := source.Peek(key)
+				if value, ok := restored.Peek(key); !ok || value != want {
+					t.Errorf("Expected %s to be %d, got %v", key, want, value)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUCacheSaveLoadAny(t *testing.T) {
+	source := New(3)
+	source.Put("a", "one")
+	source.Put(2, "two")
+
+	var buf bytes.Buffer
+	if err := source.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := New(3)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if value, ok := restored.Get(2); !ok || value != "two" {
+		t.Errorf("Expected two, got %v", value)
+	}
+}
+
+func TestLRUCacheLoadIntoSmallerCache(t *testing.T) {
+	source := NewTyped[int, int](10)
+	for i := 0; i < 10; i++ {
+		source.Put(i, i)
+	}
+
+	var buf bytes.Buffer
+	if err := source.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	var evictions int
+	restored := NewTypedWithEvict(3, func(key, value int, reason EvictReason) {
+		evictions++
+	})
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Only the three most recent entries survive, without churning through the others
+	keys := restored.Keys()
+	expected := []int{9, 8, 7}
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("Expected keys %v, got %v", expected, keys)
+			break
+		}
+	}
+	if evictions != 0 {
+		t.Errorf("Expected no evictions while loading, got %d", evictions)
+	}
+}
+
+func TestLRUCacheSaveSkipsExpired(t *testing.T) {
+	clock := newFakeClock()
+	source := NewTyped[string, int](5)
+	source.now = clock.Now
+	source.PutWithTTL("short", 1, time.Second)
+	source.PutWithTTL("long", 2, time.Hour)
+	source.Put("forever", 3)
+
+	clock.Advance(time.Minute)
+
+	var buf bytes.Buffer
+	if err := source.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored := NewTyped[string, int](5)
+	restored.now = clock.Now
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if restored.Contains("short") {
+		t.Error("Expired entries should not be saved")
+	}
+	if restored.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %v", restored.Keys())
+	}
+
+	// The remaining lifetime is carried over
+	clock.Advance(time.Hour)
+	if restored.Contains("long") {
+		t.Error("long should expire at its original time")
+	}
+	if !restored.Contains("forever") {
+		t.Error("Entries without ttl should never expire")
+	}
+}
+
+func TestLRUCacheLoadSkipsExpiredBeforeTruncating(t *testing.T) {
+	clock := newFakeClock()
+	source := NewTyped[int, int](10)
+	source.now = clock.Now
+	source.Put(1, 1)
+	source.Put(2, 2)
+	source.PutWithTTL(3, 3, time.Minute)
+	source.PutWithTTL(4, 4, time.Minute) // 4 and 3 are the most recent
+
+	var buf bytes.Buffer
+	if err := source.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// The two most recent entries expire between Save and Load
+	clock.Advance(time.Hour)
+	restored := NewTyped[int, int](2)
+	restored.now = clock.Now
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	keys := restored.Keys()
+	if len(keys) != 2 || keys[0] != 2 || keys[1] != 1 {
+		t.Errorf("Expected the live keys [2 1] to fill the cache, got %v", keys)
+	}
+}
+
+func TestLRUCacheLoadInvalidCapacity(t *testing.T) {
+	source := NewTyped[int, int](5)
+	for i := 0; i < 5; i++ {
+		source.Put(i, i)
+	}
+
+	var buf bytes.Buffer
+	if err := source.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Like Put, a cache with a capacity below 1 keeps the most recent entry
+	restored := NewTyped[int, int](-1)
+	if err := restored.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if keys := restored.Keys(); len(keys) != 1 || keys[0] != 4 {
+		t.Errorf("Expected only key 4, got %v", keys)
+	}
+}
+
+func TestLRUCacheLoadInvalid(t *testing.T) {
+	cache := NewTyped[string, int](5)
+	cache.Put("existing", 1)
+
+	input := `{"key":"a","value":1}` + "\n" + `{"key":"b","value":"not a number"}`
+	if err := cache.LoadWith(strings.NewReader(input), JSONCodec); err == nil {
+		t.Fatal("Expected an error for an invalid snapshot")
+	}
+
+	// A failed load leaves the cache untouched
+	keys := cache.Keys()
+	if len(keys) != 1 || keys[0] != "existing" {
+		t.Errorf("Expected only existing, got %v", keys)
+	}
+}
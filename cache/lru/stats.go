@@ -0,0 +1,129 @@
+package lru
+
+import "sync/atomic"
+
+// Stats snapshot of cache statistics
+type Stats struct {
+	Hits      uint64 // Get calls that found the key
+	Misses    uint64 // Get calls that did not find the key
+	Puts      uint64 // Put calls that inserted a new key
+	Updates   uint64 // Put calls that overwrote an existing key
+	Evictions uint64 // entries dropped for capacity or expiration
+	Removals  uint64 // entries dropped by Remove or Clear
+}
+
+// HitRatio returns the fraction of Get calls that were hits, zero if there were none
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// statsCounter lock-free counters behind Stats
+type statsCounter struct {
+	disabled  bool // set at construction by WithStats(false), nothing is counted
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	puts      atomic.Uint64
+	updates   atomic.Uint64
+	evictions atomic.Uint64
+	removals  atomic.Uint64
+}
+
+// recordGet counts a hit or a miss
+func (s *statsCounter) recordGet(hit bool) {
+	if s.disabled {
+		return
+	}
+	if hit {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+}
+
+// recordGets counts the hits and misses of a batch
+func (s *statsCounter) recordGets(found []bool) {
+	if s.disabled {
+		return
+	}
+	hits := uint64(0)
+	for _, ok := range found {
+		if ok {
+			hits++
+		}
+	}
+	s.hits.Add(hits)
+	s.misses.Add(uint64(len(found)) - hits)
+}
+
+// recordPut counts a Put that inserted a new key
+func (s *statsCounter) recordPut() {
+	if !s.disabled {
+		s.puts.Add(1)
+	}
+}
+
+// recordUpdate counts a Put that overwrote an existing key
+func (s *statsCounter) recordUpdate() {
+	if !s.disabled {
+		s.updates.Add(1)
+	}
+}
+
+// recordEvict counts an entry leaving the cache
+func (s *statsCounter) recordEvict(reason EvictReason, n uint64) {
+	if s.disabled {
+		return
+	}
+	switch reason {
+	case EvictReasonCapacity, EvictReasonExpired:
+		s.evictions.Add(n)
+	case EvictReasonRemoved, EvictReasonCleared:
+		s.removals.Add(n)
+	}
+}
+
+// snapshot returns the current counter values
+func (s *statsCounter) snapshot() Stats {
+	return Stats{
+		Hits:      s.hits.Load(),
+		Misses:    s.misses.Load(),
+		Puts:      s.puts.Load(),
+		Updates:   s.updates.Load(),
+		Evictions: s.evictions.Load(),
+		Removals:  s.removals.Load(),
+	}
+}
+
+// reset sets every counter back to zero
+func (s *statsCounter) reset() {
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.puts.Store(0)
+	s.updates.Store(0)
+	s.evictions.Store(0)
+	s.removals.Store(0)
+}
+
+// Stats returns a snapshot of the cache statistics
+func (c *TypedCache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats sets every statistic back to zero
+func (c *TypedCache[K, V]) ResetStats() {
+	c.stats.reset()
+}
+
+// Stats returns a snapshot of the cache statistics
+func (c *SyncMapCache) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// ResetStats sets every statistic back to zero
+func (c *SyncMapCache) ResetStats() {
+	c.stats.reset()
+}
@@ -0,0 +1,55 @@
+package lru
+
+import "testing"
+
+// statsCache is implemented by both caches so the same scenario can check them
+type statsCache interface {
+	Get(key any) (any, bool)
+	Put(key, value any)
+	Remove(key any) bool
+	Clear()
+	Stats() Stats
+	ResetStats()
+}
+
+func testStats(t *testing.T, cache statsCache) {
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // update
+	cache.Get("a")     // hit
+	cache.Get("z")     // miss
+	cache.Put("c", 3)  // evicts b
+	cache.Get("b")     // miss
+	cache.Remove("a")
+	cache.Clear() // drops c
+
+	expected := Stats{Hits: 1, Misses: 2, Puts: 3, Updates: 1, Evictions: 1, Removals: 2}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+	if ratio := cache.Stats().HitRatio(); ratio != 1.0/3.0 {
+		t.Errorf("Expected hit ratio 1/3, got %v", ratio)
+	}
+
+	cache.ResetStats()
+	if stats := cache.Stats(); stats != (Stats{}) {
+		t.Errorf("Expected zero stats after reset, got %+v", stats)
+	}
+}
+
+func TestLRUCacheStats(t *testing.T) {
+	testStats(t, New(2))
+}
+
+func TestSyncMapCacheStats(t *testing.T) {
+	testStats(t, NewSyncMap(2))
+}
+
+func TestStatsHitRatio(t *testing.T) {
+	if ratio := (Stats{}).HitRatio(); ratio != 0 {
+		t.Errorf("Expected hit ratio 0 without lookups, got %v", ratio)
+	}
+	if ratio := (Stats{Hits: 3, Misses: 1}).HitRatio(); ratio != 0.75 {
+		t.Errorf("Expected hit ratio 0.75, got %v", ratio)
+	}
+}
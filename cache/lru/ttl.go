@@ -0,0 +1,115 @@
+package lru
+
+import (
+	"runtime"
+	"sync"
+	"time"
+	"weak"
+)
+
+// NewWithTTL creates a new LRU cache whose entries expire ttl after they were put.
+// A background janitor sweeps expired entries every ttl; call Close to stop it.
+// The janitor does not keep the cache alive, it also stops once the cache is collected.
+func NewWithTTL(capacity int, ttl time.Duration) *Cache {
+	return NewTypedWithTTL[any, any](capacity, ttl)
+}
+
+// NewTypedWithTTL creates a new type-safe LRU cache whose entries expire ttl after they were put.
+// A background janitor sweeps expired entries every ttl; call Close to stop it.
+func NewTypedWithTTL[K comparable, V any](capacity int, ttl time.Duration) *TypedCache[K, V] {
+	c := NewTyped[K, V](capacity)
+	c.setTTL(ttl)
+	return c
+}
+
+// setTTL sets the default time to live and starts the janitor, only during construction
+func (c *TypedCache[K, V]) setTTL(ttl time.Duration) {
+	c.ttl = ttl
+	if ttl <= 0 {
+		return
+	}
+
+	// The janitor only holds a weak pointer so that a cache whose owner forgot to
+	// Close it can still be collected, the cleanup then stops the janitor
+	cache := weak.Make(c)
+	c.janitor = newJanitor(ttl, func() {
+		if c := cache.Value(); c != nil {
+			c.deleteExpired()
+		}
+	})
+	runtime.AddCleanup(c, (*janitor).close, c.janitor)
+}
+
+// PutWithTTL adds a key-value pair that expires after ttl, a non-positive ttl means it never expires
+func (c *TypedCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.mutex.Lock()
+	c.put(key, value, c.weigh(key, value), c.expiresAt(ttl))
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+}
+
+// Close stops the background janitor, it is safe to call more than once
+func (c *TypedCache[K, V]) Close() {
+	if c.janitor != nil {
+		c.janitor.close()
+	}
+}
+
+// expiresAt returns the expiration time for an entry put now with the given ttl
+func (c *TypedCache[K, V]) expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.now().Add(ttl)
+}
+
+// deleteExpired removes every expired entry and remembered loader error from the cache
+func (c *TypedCache[K, V]) deleteExpired() {
+	c.mutex.Lock()
+	now := c.now()
+	for element := c.list.Back(); element != nil; {
+		prev := element.Prev()
+		if element.Value.(*entry[K, V]).expired(now) {
+			c.removeElement(element, EvictReasonExpired)
+		}
+		element = prev
+	}
+	c.negative.deleteExpired(now)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+}
+
+// janitor periodically sweeps expired entries until it is closed
+type janitor struct {
+	stop chan struct{}
+	once sync.Once
+}
+
+// newJanitor starts a goroutine that calls sweep every interval
+func newJanitor(interval time.Duration, sweep func()) *janitor {
+	j := &janitor{stop: make(chan struct{})}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweep()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+	return j
+}
+
+// close stops the janitor goroutine
+func (j *janitor) close() {
+	j.once.Do(func() {
+		close(j.stop)
+	})
+}
@@ -0,0 +1,172 @@
+package lru
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock a manually advanced clock for expiration tests
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func TestLRUCachePutWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := New(3)
+	cache.now = clock.Now
+
+	cache.PutWithTTL("short", 1, time.Second)
+	cache.PutWithTTL("long", 2, time.Minute)
+	cache.Put("forever", 3)
+
+	clock.Advance(2 * time.Second)
+
+	if _, ok := cache.Peek("short"); ok {
+		t.Error("Peek should treat an expired entry as a miss")
+	}
+	if cache.Contains("short") {
+		t.Error("Contains should treat an expired entry as a miss")
+	}
+	if _, ok := cache.Get("short"); ok {
+		t.Error("Get should treat an expired entry as a miss")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Get should drop the expired entry, expected length 2, got %d", cache.Len())
+	}
+
+	if value, ok := cache.Get("long"); !ok || value != 2 {
+		t.Errorf("Expected long to be 2, got %v", value)
+	}
+
+	clock.Advance(time.Hour)
+
+	if _, ok := cache.Get("long"); ok {
+		t.Error("long should have expired")
+	}
+	if value, ok := cache.Get("forever"); !ok || value != 3 {
+		t.Errorf("Entries without ttl should never expire, got %v", value)
+	}
+}
+
+func TestLRUCacheDefaultTTL(t *testing.T) {
+	clock := newFakeClock()
+	cache := NewWithTTL(3, time.Minute)
+	defer cache.Close()
+	cache.now = clock.Now
+
+	cache.Put("a", 1)
+	clock.Advance(30 * time.Second)
+
+	// Updating an entry restarts its ttl
+	cache.Put("a", 2)
+	clock.Advance(45 * time.Second)
+
+	if value, ok := cache.Get("a"); !ok || value != 2 {
+		t.Errorf("Expected a to be 2, got %v", value)
+	}
+
+	clock.Advance(time.Minute)
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("a should have expired")
+	}
+}
+
+func TestLRUCacheExpiredKeys(t *testing.T) {
+	clock := newFakeClock()
+	cache := New(3)
+	cache.now = clock.Now
+
+	cache.PutWithTTL("a", 1, time.Second)
+	cache.Put("b", 2)
+
+	clock.Advance(time.Second)
+
+	keys := cache.Keys()
+	if len(keys) != 1 || keys[0] != "b" {
+		t.Errorf("Expected only b, got %v", keys)
+	}
+}
+
+func TestLRUCacheExpiredCallback(t *testing.T) {
+	clock := newFakeClock()
+	var reasons []EvictReason
+	cache := NewWithEvict(3, func(key, value any, reason EvictReason) {
+		reasons = append(reasons, reason)
+	})
+	cache.now = clock.Now
+
+	cache.PutWithTTL("a", 1, time.Second)
+	clock.Advance(time.Second)
+
+	// Putting over an expired entry reports it as expired, not replaced
+	cache.Put("a", 2)
+
+	if len(reasons) != 1 || reasons[0] != EvictReasonExpired {
+		t.Errorf("Expected one expired callback, got %v", reasons)
+	}
+}
+
+func TestLRUCacheJanitor(t *testing.T) {
+	cache := NewWithTTL(10, 10*time.Millisecond)
+	defer cache.Close()
+
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+
+	// Nobody reads the keys, the janitor alone has to reclaim them
+	deadline := time.Now().Add(time.Second)
+	for cache.Len() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Janitor did not sweep expired entries, length %d", cache.Len())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLRUCacheClose(t *testing.T) {
+	cache := NewWithTTL(10, time.Millisecond)
+
+	cache.Close()
+	cache.Close() // Close must be idempotent
+
+	// A cache without a janitor can be closed too
+	New(1).Close()
+}
+
+func TestLRUCacheJanitorStopsWhenCollected(t *testing.T) {
+	// Keep the janitor but drop the cache without calling Close
+	j := NewWithTTL(10, time.Millisecond).janitor
+
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case <-j.stop:
+			return
+		case <-deadline:
+			t.Fatal("The janitor of an unreferenced cache should stop once the cache is collected")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"container/list"
+	"math/rand/v2"
+)
+
+// listPolicy keeps keys in a list, newest or most recently used at the front
+type listPolicy struct {
+	list          *list.List
+	elements      map[any]*list.Element
+	moveOnAccess  bool // move accessed keys to the front
+	evictFromBack bool // take victims from the back instead of the front
+}
+
+func newListPolicy(moveOnAccess, evictFromBack bool) listPolicy {
+	return listPolicy{
+		list:          list.New(),
+		elements:      make(map[any]*list.Element),
+		moveOnAccess:  moveOnAccess,
+		evictFromBack: evictFromBack,
+	}
+}
+
+// OnInsert adds a key at the front
+func (p *listPolicy) OnInsert(key any) {
+	p.elements[key] = p.list.PushFront(key)
+}
+
+// OnAccess moves a key to the front if the policy orders by use
+func (p *listPolicy) OnAccess(key any) {
+	if p.moveOnAccess {
+		p.list.MoveToFront(p.elements[key])
+	}
+}
+
+// OnRemove forgets a key
+func (p *listPolicy) OnRemove(key any) {
+	if element, ok := p.elements[key]; ok {
+		p.list.Remove(element)
+		delete(p.elements, key)
+	}
+}
+
+// Victim returns the key at the evicting end of the list
+func (p *listPolicy) Victim() (any, bool) {
+	element := p.list.Front()
+	if p.evictFromBack {
+		element = p.list.Back()
+	}
+	if element == nil {
+		return nil, false
+	}
+	return element.Value, true
+}
+
+// Keys returns all keys, the one evicted last first
+func (p *listPolicy) Keys() []any {
+	keys := make([]any, 0, p.list.Len())
+	if p.evictFromBack {
+		for element := p.list.Front(); element != nil; element = element.Next() {
+			keys = append(keys, element.Value)
+		}
+	} else {
+		for element := p.list.Back(); element != nil; element = element.Prev() {
+			keys = append(keys, element.Value)
+		}
+	}
+	return keys
+}
+
+// LRU evicts the least recently used key
+type LRU struct{ listPolicy }
+
+// NewLRU creates a least recently used policy
+func NewLRU() *LRU {
+	return &LRU{newListPolicy(true, true)}
+}
+
+// MRU evicts the most recently used key, which suits cyclic scans larger than the cache
+type MRU struct{ listPolicy }
+
+// NewMRU creates a most recently used policy
+func NewMRU() *MRU {
+	return &MRU{newListPolicy(true, false)}
+}
+
+// FIFO evicts the oldest key regardless of use
+type FIFO struct{ listPolicy }
+
+// NewFIFO creates a first in, first out policy
+func NewFIFO() *FIFO {
+	return &FIFO{newListPolicy(false, true)}
+}
+
+// LIFO evicts the newest key regardless of use
+type LIFO struct{ listPolicy }
+
+// NewLIFO creates a last in, first out policy
+func NewLIFO() *LIFO {
+	return &LIFO{newListPolicy(false, false)}
+}
+
+// Random evicts a key chosen uniformly at random
+type Random struct {
+	keys    []any
+	indexes map[any]int // key -> position in keys
+	rand    *rand.Rand
+}
+
+// NewRandom creates a random policy, the same seed gives the same evictions
+func NewRandom(seed uint64) *Random {
+	return &Random{
+		indexes: make(map[any]int),
+		rand:    rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// OnInsert adds a key
+func (p *Random) OnInsert(key any) {
+	p.indexes[key] = len(p.keys)
+	p.keys = append(p.keys, key)
+}
+
+// OnAccess does nothing, random eviction ignores use
+func (p *Random) OnAccess(key any) {}
+
+// OnRemove forgets a key by moving the last key into its place
+func (p *Random) OnRemove(key any) {
+	i, ok := p.indexes[key]
+	if !ok {
+		return
+	}
+	last := len(p.keys) - 1
+	p.keys[i] = p.keys[last]
+	p.indexes[p.keys[i]] = i
+	p.keys[last] = nil
+	p.keys = p.keys[:last]
+	delete(p.indexes, key)
+}
+
+// Victim returns a random key
+func (p *Random) Victim() (any, bool) {
+	if len(p.keys) == 0 {
+		return nil, false
+	}
+	return p.keys[p.rand.IntN(len(p.keys))], true
+}
+
+var (
+	_ EvictionPolicy = (*LRU)(nil)
+	_ EvictionPolicy = (*MRU)(nil)
+	_ EvictionPolicy = (*FIFO)(nil)
+	_ EvictionPolicy = (*LIFO)(nil)
+	_ EvictionPolicy = (*Random)(nil)
+	_ Ordered        = (*LRU)(nil)
+)
@@ -0,0 +1,183 @@
+package policy
+
+import (
+	"testing"
+)
+
+// checkKeys compares the keys of a cache, the one evicted last first, with the expected keys
+func checkKeys(t *testing.T, c *Cache, expected ...any) {
+	t.Helper()
+
+	keys := c.Keys()
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Fatalf("Expected keys %v, got %v", expected, keys)
+		}
+	}
+}
+
+func TestLRUPolicy(t *testing.T) {
+	cache := New(3, NewLRU())
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Get(1)
+	checkKeys(t, cache, 1, 3, 2)
+
+	cache.Put(4, 4)
+	checkKeys(t, cache, 4, 1, 3)
+}
+
+func TestMRUPolicy(t *testing.T) {
+	cache := New(3, NewMRU())
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Get(1)
+	checkKeys(t, cache, 2, 3, 1)
+
+	// The most recently used key 1 is evicted
+	cache.Put(4, 4)
+	checkKeys(t, cache, 2, 3, 4)
+}
+
+func TestFIFOPolicy(t *testing.T) {
+	cache := New(3, NewFIFO())
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Get(1)
+	cache.Put(1, 10)
+
+	// Use does not matter, the oldest key goes first
+	cache.Put(4, 4)
+	checkKeys(t, cache, 4, 3, 2)
+}
+
+func TestLIFOPolicy(t *testing.T) {
+	cache := New(3, NewLIFO())
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Get(1)
+
+	// The newest key goes first
+	cache.Put(4, 4)
+	checkKeys(t, cache, 1, 2, 4)
+
+	cache.Put(5, 5)
+	checkKeys(t, cache, 1, 2, 5)
+}
+
+func TestRandomPolicy(t *testing.T) {
+	evictions := func(seed uint64) []any {
+		var evicted []any
+		cache := New(10, NewRandom(seed))
+		for i := 0; i < 100; i++ {
+			cache.Put(i, i)
+		}
+		for i := 0; i < 100; i++ {
+			if !cache.Contains(i) {
+				evicted = append(evicted, i)
+			}
+		}
+		return evicted
+	}
+
+	// The same seed gives the same evictions
+	first, second := evictions(7), evictions(7)
+	if len(first) != 90 || len(second) != 90 {
+		t.Fatalf("Expected 90 evictions, got %d and %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatal("Expected the same evictions for the same seed")
+		}
+	}
+
+	// Unlike FIFO, some keys older than the newest ten survive
+	survivors := 0
+	cache := New(10, NewRandom(7))
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < 90; i++ {
+		if cache.Contains(i) {
+			survivors++
+		}
+	}
+	if survivors == 0 {
+		t.Error("Expected some of the older keys to survive random eviction")
+	}
+}
+
+func TestRandomPolicyRemove(t *testing.T) {
+	p := NewRandom(1)
+
+	for i := 0; i < 5; i++ {
+		p.OnInsert(i)
+	}
+	p.OnRemove(0)
+	p.OnRemove(4)
+	p.OnRemove(42)
+
+	if len(p.keys) != 3 || len(p.indexes) != 3 {
+		t.Fatalf("Expected 3 keys, got %v", p.keys)
+	}
+	for i, key := range p.keys {
+		if p.indexes[key] != i {
+			t.Errorf("Index of %v is %d, want %d", key, p.indexes[key], i)
+		}
+	}
+
+	for range 3 {
+		victim, ok := p.Victim()
+		if !ok {
+			t.Fatal("Expected a victim")
+		}
+		p.OnRemove(victim)
+	}
+	if _, ok := p.Victim(); ok {
+		t.Error("Expected no victim once every key is removed")
+	}
+}
+
+func TestListPolicyEmpty(t *testing.T) {
+	for _, p := range []EvictionPolicy{NewLRU(), NewMRU(), NewFIFO(), NewLIFO()} {
+		if _, ok := p.Victim(); ok {
+			t.Errorf("%T: expected no victim from an empty policy", p)
+		}
+		// Forgetting an unknown key is harmless
+		p.OnRemove("missing")
+	}
+}
+
+// Benchmark tests
+func BenchmarkPolicyCacheMixed(b *testing.B) {
+	policies := map[string]func() EvictionPolicy{
+		"LRU":    func() EvictionPolicy { return NewLRU() },
+		"FIFO":   func() EvictionPolicy { return NewFIFO() },
+		"Random": func() EvictionPolicy { return NewRandom(1) },
+	}
+	for name, newPolicy := range policies {
+		b.Run(name, func(b *testing.B) {
+			cache := New(1000, newPolicy())
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if i%2 == 0 {
+					cache.Put(i, i)
+				} else {
+					cache.Get(i % 1000)
+				}
+			}
+		})
+	}
+}
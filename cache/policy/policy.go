@@ -0,0 +1,245 @@
+// Package policy implements a cache whose eviction order is decided by a pluggable
+// EvictionPolicy. The cache owns the key map, locking, statistics and eviction
+// callbacks; a policy only tracks keys and picks the next victim.
+package policy
+
+import (
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+// EvictionPolicy decides which key leaves a full cache.
+//
+// The cache calls every method while holding its write lock, so implementations
+// need no locking of their own, but an instance must not be shared between caches.
+// Keys passed to OnAccess and OnRemove have always been passed to OnInsert before.
+type EvictionPolicy interface {
+	// OnInsert is called after a new key was added
+	OnInsert(key any)
+	// OnAccess is called when a key is read with Get or overwritten with Put
+	OnAccess(key any)
+	// OnRemove is called after a key left the cache for any reason,
+	// including being returned by Victim
+	OnRemove(key any)
+	// Victim returns the key to evict next without forgetting it, or false if
+	// the policy tracks no keys
+	Victim() (any, bool)
+}
+
+// Ordered is implemented by policies that can list their keys in a meaningful
+// order. Cache.Keys uses it when available.
+type Ordered interface {
+	// Keys returns all tracked keys, the one evicted last first
+	Keys() []any
+}
+
+// Cache cache structure delegating eviction decisions to an EvictionPolicy
+type Cache struct {
+	capacity int
+	cache    map[any]any
+	policy   EvictionPolicy
+	mutex    sync.RWMutex
+	onEvict  lru.EvictCallback[any, any]
+	evicted  []evictedEntry // entries to report once the lock is released
+	stats    lru.Stats      // guarded by the mutex
+}
+
+// evictedEntry an entry that left the cache while the lock was held
+type evictedEntry struct {
+	key    any
+	value  any
+	reason lru.EvictReason
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new cache evicting keys in the order chosen by policy
+func New(capacity int, policy EvictionPolicy) *Cache {
+	return &Cache{
+		capacity: capacity,
+		cache:    make(map[any]any),
+		policy:   policy,
+	}
+}
+
+// NewWithEvict creates a new cache that reports evicted entries to onEvict
+func NewWithEvict(capacity int, policy EvictionPolicy, onEvict lru.EvictCallback[any, any]) *Cache {
+	c := New(capacity, policy)
+	c.onEvict = onEvict
+	return c
+}
+
+// Get retrieves a value from the cache
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	value, ok := c.cache[key]
+	if ok {
+		c.policy.OnAccess(key)
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok
+}
+
+// Put adds a key-value pair to the cache
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	c.put(key, value)
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+}
+
+// put adds a key-value pair, the caller must hold the write lock
+func (c *Cache) put(key, value any) {
+	if old, ok := c.cache[key]; ok {
+		c.addEvicted(key, old, lru.EvictReasonReplaced)
+		c.cache[key] = value
+		c.policy.OnAccess(key)
+		c.stats.Updates++
+		return
+	}
+
+	// If the cache is full, ask the policy for victims
+	for len(c.cache) >= c.capacity {
+		victim, ok := c.policy.Victim()
+		if !ok {
+			break
+		}
+		if _, ok := c.cache[victim]; !ok {
+			panic("policy: Victim returned a key that is not in the cache")
+		}
+		c.remove(victim, lru.EvictReasonCapacity)
+	}
+
+	c.cache[key] = value
+	c.policy.OnInsert(key)
+	c.stats.Puts++
+}
+
+// remove drops a key, the caller must hold the write lock
+func (c *Cache) remove(key any, reason lru.EvictReason) {
+	value := c.cache[key]
+	delete(c.cache, key)
+	c.policy.OnRemove(key)
+	c.addEvicted(key, value, reason)
+	if reason == lru.EvictReasonCapacity {
+		c.stats.Evictions++
+	} else {
+		c.stats.Removals++
+	}
+}
+
+// addEvicted records an entry for the eviction callback, the caller must hold the write lock
+func (c *Cache) addEvicted(key, value any, reason lru.EvictReason) {
+	if c.onEvict != nil {
+		c.evicted = append(c.evicted, evictedEntry{key: key, value: value, reason: reason})
+	}
+}
+
+// takeEvicted hands over the recorded entries, the caller must hold the write lock
+func (c *Cache) takeEvicted() []evictedEntry {
+	entries := c.evicted
+	c.evicted = nil
+	return entries
+}
+
+// notify runs the eviction callback, the caller must not hold the lock
+func (c *Cache) notify(entries []evictedEntry) {
+	for _, e := range entries {
+		c.onEvict(e.key, e.value, e.reason)
+	}
+}
+
+// Remove removes a key from the cache
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	_, ok := c.cache[key]
+	if ok {
+		c.remove(key, lru.EvictReasonRemoved)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+	return ok
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.cache)
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	for key := range c.cache {
+		c.remove(key, lru.EvictReasonCleared)
+	}
+	evicted := c.takeEvicted()
+	c.mutex.Unlock()
+
+	c.notify(evicted)
+}
+
+// Keys returns all keys in the cache. If the policy implements Ordered the key
+// evicted last comes first, otherwise the order is unspecified.
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if ordered, ok := c.policy.(Ordered); ok {
+		return ordered.Keys()
+	}
+	keys := make([]any, 0, len(c.cache))
+	for key := range c.cache {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without telling the policy
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	value, ok := c.cache[key]
+	return value, ok
+}
+
+// Stats returns a snapshot of the cache statistics
+func (c *Cache) Stats() lru.Stats {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.stats
+}
+
+// ResetStats sets every statistic back to zero
+func (c *Cache) ResetStats() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stats = lru.Stats{}
+}
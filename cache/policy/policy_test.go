@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+// smallestFirst evicts the smallest int key, a policy written outside the built-ins
+type smallestFirst struct {
+	keys map[any]bool
+}
+
+func (p *smallestFirst) OnInsert(key any) { p.keys[key] = true }
+func (p *smallestFirst) OnAccess(key any) {}
+func (p *smallestFirst) OnRemove(key any) { delete(p.keys, key) }
+
+func (p *smallestFirst) Victim() (any, bool) {
+	victim, found := 0, false
+	for key := range p.keys {
+		if k := key.(int); !found || k < victim {
+			victim, found = k, true
+		}
+	}
+	return victim, found
+}
+
+// evictRecord one call of the eviction callback
+type evictRecord struct {
+	key    any
+	value  any
+	reason lru.EvictReason
+}
+
+func TestPolicyCache(t *testing.T) {
+	cache := New(2, NewLRU())
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit
+	cache.Put("key3", "value3") // This should evict key2
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestPolicyCacheCustomPolicy(t *testing.T) {
+	cache := New(3, &smallestFirst{keys: make(map[any]bool)})
+
+	cache.Put(5, "five")
+	cache.Put(1, "one")
+	cache.Put(9, "nine")
+	cache.Get(1)
+
+	cache.Put(7, "seven")
+	if cache.Contains(1) {
+		t.Error("key 1 should have been evicted as the smallest key")
+	}
+
+	// The victim is chosen before the new key is inserted
+	cache.Put(3, "three")
+	if cache.Contains(5) {
+		t.Error("key 5 should have been evicted as the smallest key")
+	}
+	if !cache.Contains(3) {
+		t.Error("key 3 should be in cache")
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", cache.Len())
+	}
+}
+
+func TestPolicyCacheBadVictim(t *testing.T) {
+	cache := New(1, &smallestFirst{keys: map[any]bool{-1: true}})
+	cache.Put(1, 1)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when the policy returns an unknown victim")
+		}
+	}()
+	cache.Put(2, 2)
+}
+
+func TestPolicyCacheEvictCallback(t *testing.T) {
+	var records []evictRecord
+	cache := NewWithEvict(2, NewFIFO(), func(key, value any, reason lru.EvictReason) {
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10)
+	cache.Put("c", 3)
+	cache.Remove("b")
+	cache.Clear()
+
+	expected := []evictRecord{
+		{"a", 1, lru.EvictReasonReplaced},
+		{"a", 10, lru.EvictReasonCapacity},
+		{"b", 2, lru.EvictReasonRemoved},
+		{"c", 3, lru.EvictReasonCleared},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d callbacks, got %v", len(expected), records)
+	}
+	for i := range expected {
+		if records[i] != expected[i] {
+			t.Errorf("Callback %d: expected %v, got %v", i, expected[i], records[i])
+		}
+	}
+}
+
+func TestPolicyCacheCallbackReentry(t *testing.T) {
+	var cache *Cache
+	cache = NewWithEvict(1, NewLRU(), func(key, value any, reason lru.EvictReason) {
+		// The lock is released before the callback runs
+		cache.Len()
+	})
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+}
+
+func TestPolicyCacheStats(t *testing.T) {
+	cache := New(2, NewLRU())
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(2, 20)
+	cache.Get(1)
+	cache.Get(3)
+	cache.Put(3, 3)
+	cache.Remove(1)
+
+	expected := lru.Stats{Hits: 1, Misses: 1, Puts: 3, Updates: 1, Evictions: 1, Removals: 1}
+	if stats := cache.Stats(); stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	cache.ResetStats()
+	if stats := cache.Stats(); stats != (lru.Stats{}) {
+		t.Errorf("Expected zero stats after reset, got %+v", stats)
+	}
+}
+
+func TestPolicyCacheConformance(t *testing.T) {
+	policies := map[string]func() EvictionPolicy{
+		"LRU":    func() EvictionPolicy { return NewLRU() },
+		"MRU":    func() EvictionPolicy { return NewMRU() },
+		"FIFO":   func() EvictionPolicy { return NewFIFO() },
+		"LIFO":   func() EvictionPolicy { return NewLIFO() },
+		"Random": func() EvictionPolicy { return NewRandom(1) },
+		"Custom": func() EvictionPolicy { return &smallestFirst{keys: make(map[any]bool)} },
+	}
+	for name, newPolicy := range policies {
+		t.Run(name, func(t *testing.T) {
+			cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity, newPolicy()) })
+		})
+	}
+}
+
+func TestPolicyCacheConcurrency(t *testing.T) {
+	cache := New(100, NewLRU())
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Keys()
+			cache.Stats()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
@@ -0,0 +1,178 @@
+package sieve
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Cache SIEVE cache structure.
+//
+// Entries are kept in a single FIFO queue, newest at the front. A hit only sets the
+// entry's visited bit, so Get runs under a read lock. To evict, the hand moves from
+// the back of the queue towards the front, clearing visited bits until it finds an
+// unvisited entry. Unlike CLOCK, surviving entries keep their position instead of
+// being moved, so new entries that are never hit again are evicted quickly.
+type Cache struct {
+	capacity int
+	cache    map[any]*list.Element
+	list     *list.List    // newest first
+	hand     *list.Element // where the next eviction search starts, nil for the back
+	mutex    sync.RWMutex
+}
+
+// entry cache entry
+type entry struct {
+	key     any
+	value   any
+	visited atomic.Bool
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new SIEVE cache
+func New(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		cache:    make(map[any]*list.Element),
+		list:     list.New(),
+	}
+}
+
+// Get retrieves a value from the cache and marks it as visited
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		e := element.Value.(*entry)
+		// Skip the store when the bit is already set to keep the cache line shared
+		if !e.visited.Load() {
+			e.visited.Store(true)
+		}
+		return e.value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		e := element.Value.(*entry)
+		e.value = value
+		e.visited.Store(true)
+		return
+	}
+
+	// If the cache is full, let the hand find a victim
+	if c.list.Len() >= c.capacity {
+		c.evict()
+	}
+
+	e := &entry{key: key, value: value}
+	c.cache[key] = c.list.PushFront(e)
+}
+
+// evict moves the hand towards the front, clearing visited bits, and removes the
+// first unvisited entry. The hand wraps around to the back of the queue.
+func (c *Cache) evict() {
+	element := c.hand
+	if element == nil {
+		element = c.list.Back()
+	}
+	for element != nil {
+		if !element.Value.(*entry).visited.Swap(false) {
+			break
+		}
+		element = element.Prev()
+		if element == nil {
+			element = c.list.Back()
+		}
+	}
+	if element == nil {
+		return
+	}
+	c.hand = element.Prev()
+	c.removeElement(element)
+}
+
+// removeElement removes a specific element, moving the hand past it
+func (c *Cache) removeElement(element *list.Element) {
+	if c.hand == element {
+		c.hand = element.Prev()
+	}
+	c.list.Remove(element)
+	delete(c.cache, element.Value.(*entry).key)
+}
+
+// Remove removes a key from the cache
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		c.removeElement(element)
+		return true
+	}
+	return false
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.list.Len()
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.cache = make(map[any]*list.Element)
+	c.list.Init()
+	c.hand = nil
+}
+
+// Keys returns all keys in the cache in insertion order, newest first
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, c.list.Len())
+	for element := c.list.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*entry).key)
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without marking it as visited
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
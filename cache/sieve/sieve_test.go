@@ -0,0 +1,316 @@
+package sieve
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+)
+
+// checkKeys compares the queue, newest first, with the expected keys
+func checkKeys(t *testing.T, c *Cache, expected ...any) {
+	t.Helper()
+
+	keys := c.Keys()
+	if len(keys) != len(expected) {
+		t.Fatalf("Expected keys %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Fatalf("Expected keys %v, got %v", expected, keys)
+		}
+	}
+}
+
+func TestSieveCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, key1 was visited so key2 goes
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestSieveCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestSieveCacheEviction(t *testing.T) {
+	cache := New(3)
+
+	// Fill up the cache, the queue is 3 2 1
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+	cache.Put(3, "three")
+
+	// Mark key 1 as visited
+	cache.Get(1)
+
+	// The hand starts at the back: 1 is visited and spared, 2 is evicted.
+	// Unlike LRU, key 1 keeps its place at the back of the queue.
+	cache.Put(4, "four")
+
+	if cache.Contains(2) {
+		t.Error("key 2 should have been evicted")
+	}
+	checkKeys(t, cache, 4, 3, 1)
+
+	// The hand stayed at 3, which is not visited
+	cache.Put(5, "five")
+
+	if cache.Contains(3) {
+		t.Error("key 3 should have been evicted")
+	}
+	checkKeys(t, cache, 5, 4, 1)
+
+	// The hand is at 4: 4 is visited and spared, 5 is evicted
+	cache.Get(4)
+	cache.Put(6, "six")
+
+	if cache.Contains(5) {
+		t.Error("key 5 should have been evicted")
+	}
+	checkKeys(t, cache, 6, 4, 1)
+
+	// The hand passed the front and wraps around to 1, whose bit was cleared
+	cache.Put(7, "seven")
+
+	if cache.Contains(1) {
+		t.Error("key 1 should have been evicted")
+	}
+	checkKeys(t, cache, 7, 6, 4)
+}
+
+func TestSieveCacheWrapAround(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Every key is visited, the hand clears a, b and c, wraps around and evicts a
+	cache.Get("a")
+	cache.Get("b")
+	cache.Get("c")
+	cache.Put("d", 4)
+
+	checkKeys(t, cache, "d", "c", "b")
+
+	// The hand now points at b, which lost its visited bit
+	cache.Put("e", 5)
+
+	checkKeys(t, cache, "e", "d", "c")
+}
+
+func TestSieveCacheRemoveUnderHand(t *testing.T) {
+	cache := New(3)
+
+	cache.Put(1, 1)
+	cache.Put(2, 2)
+	cache.Put(3, 3)
+	cache.Put(4, 4) // evicts 1, the hand moves to 2
+
+	// Removing the entry under the hand moves the hand on to 3
+	cache.Remove(2)
+	cache.Put(5, 5)
+	cache.Put(6, 6)
+
+	if cache.Contains(3) {
+		t.Error("key 3 should have been evicted")
+	}
+	checkKeys(t, cache, 6, 5, 4)
+}
+
+func TestSieveCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+}
+
+func TestSieveCachePeek(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	// Peek should not mark the key as visited
+	if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+		t.Errorf("Peek should return value1, got %v", value)
+	}
+
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+}
+
+func TestSieveCacheClear(t *testing.T) {
+	cache := New(3)
+
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+	if cache.hand != nil {
+		t.Error("Clear should reset the hand")
+	}
+
+	if _, ok := cache.Get(0); ok {
+		t.Error("Cache should be empty after clear")
+	}
+}
+
+func TestSieveCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestSieveCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkSieveCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkSieveCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkSieveCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestSieveCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
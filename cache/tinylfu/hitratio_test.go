@@ -0,0 +1,72 @@
+package tinylfu
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+// zipfTrace generates n keys out of keySpace following a Zipf distribution with exponent s
+func zipfTrace(n, keySpace int, s float64) []uint64 {
+	zipf := rand.NewZipf(rand.New(rand.NewSource(1)), s, 1, uint64(keySpace-1))
+	trace := make([]uint64, n)
+	for i := range trace {
+		trace[i] = zipf.Uint64()
+	}
+	return trace
+}
+
+// hitRatio replays a trace, putting every missed key, and returns the share of hits
+func hitRatio(c cache.Cache, trace []uint64) float64 {
+	hits := 0
+	for _, key := range trace {
+		if _, ok := c.Get(key); ok {
+			hits++
+		} else {
+			c.Put(key, key)
+		}
+	}
+	return float64(hits) / float64(len(trace))
+}
+
+func TestTinyLFUCacheHitRatio(t *testing.T) {
+	trace := zipfTrace(200000, 100000, 1.1)
+
+	lruRatio := hitRatio(lru.New(1000), trace)
+	tinyRatio := hitRatio(New(1000), trace)
+
+	if tinyRatio <= lruRatio {
+		t.Errorf("Expected W-TinyLFU to beat LRU on a Zipf workload, got %.3f vs %.3f", tinyRatio, lruRatio)
+	}
+}
+
+// BenchmarkHitRatio reports the hit ratio of LRU and W-TinyLFU on Zipf workloads
+// of different skew, run with -bench=HitRatio to compare them
+func BenchmarkHitRatio(b *testing.B) {
+	const capacity = 1000
+	const keySpace = 100000
+
+	caches := []struct {
+		name     string
+		newCache func() cache.Cache
+	}{
+		{"LRU", func() cache.Cache { return lru.New(capacity) }},
+		{"TinyLFU", func() cache.Cache { return New(capacity) }},
+	}
+
+	for _, s := range []float64{1.01, 1.1, 1.3} {
+		trace := zipfTrace(100000, keySpace, s)
+		for _, cc := range caches {
+			b.Run(fmt.Sprintf("zipf=%.2f/%s", s, cc.name), func(b *testing.B) {
+				var ratio float64
+				for i := 0; i < b.N; i++ {
+					ratio = hitRatio(cc.newCache(), trace)
+				}
+				b.ReportMetric(ratio*100, "hit%")
+			})
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package tinylfu
+
+import (
+	"hash/maphash"
+)
+
+const (
+	sketchDepth = 4
+	maxCount    = 15 // counters saturate like 4-bit counters
+)
+
+// sketch count-min sketch estimating how often keys were seen recently.
+// Once it has counted sampleSize additions every counter is halved, so old
+// popularity fades and the sketch follows a changing workload.
+type sketch struct {
+	counters   [sketchDepth][]uint8
+	mask       uint64
+	seed       maphash.Seed
+	additions  int
+	sampleSize int
+}
+
+// newSketch creates a sketch with four counters per row for every cache slot,
+// keeping collisions rare enough for the estimates to be useful
+func newSketch(capacity int) *sketch {
+	width := 16
+	for width < 4*capacity {
+		width <<= 1
+	}
+	s := &sketch{
+		mask:       uint64(width - 1),
+		seed:       maphash.MakeSeed(),
+		sampleSize: 10 * max(capacity, 1),
+	}
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// index returns the counter of a key in row i
+func (s *sketch) index(h uint64, i int) uint64 {
+	return (h + uint64(i)*((h>>32)|1)) & s.mask
+}
+
+// increment counts one more occurrence of a key
+func (s *sketch) increment(key any) {
+	h := maphash.Comparable(s.seed, key)
+	added := false
+	for i := range s.counters {
+		if counter := &s.counters[i][s.index(h, i)]; *counter < maxCount {
+			*counter++
+			added = true
+		}
+	}
+	if added {
+		s.additions++
+		if s.additions >= s.sampleSize {
+			s.age()
+		}
+	}
+}
+
+// estimate returns the smallest counter of a key, an upper bound of its frequency
+func (s *sketch) estimate(key any) int {
+	h := maphash.Comparable(s.seed, key)
+	count := uint8(maxCount)
+	for i := range s.counters {
+		count = min(count, s.counters[i][s.index(h, i)])
+	}
+	return int(count)
+}
+
+// age halves every counter
+func (s *sketch) age() {
+	for i := range s.counters {
+		for j := range s.counters[i] {
+			s.counters[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// reset forgets every count
+func (s *sketch) reset() {
+	for i := range s.counters {
+		clear(s.counters[i])
+	}
+	s.additions = 0
+}
@@ -0,0 +1,68 @@
+package tinylfu
+
+import (
+	"testing"
+)
+
+func TestSketchEstimate(t *testing.T) {
+	s := newSketch(100)
+
+	for i := 0; i < 100; i++ {
+		for j := 0; j <= i%10; j++ {
+			s.increment(i)
+		}
+	}
+
+	// A count-min sketch may overestimate but never underestimates
+	for i := 0; i < 100; i++ {
+		if got := s.estimate(i); got < i%10+1 {
+			t.Errorf("estimate(%d) = %d, want at least %d", i, got, i%10+1)
+		}
+	}
+
+	if got := s.estimate("never seen"); got > 10 {
+		t.Errorf("estimate of an unseen key = %d, too high", got)
+	}
+}
+
+func TestSketchSaturates(t *testing.T) {
+	s := newSketch(100)
+
+	for i := 0; i < 100; i++ {
+		s.increment("hot")
+	}
+
+	if got := s.estimate("hot"); got != maxCount {
+		t.Errorf("Expected the counter to saturate at %d, got %d", maxCount, got)
+	}
+}
+
+func TestSketchAging(t *testing.T) {
+	s := newSketch(10) // halves after 100 additions
+
+	for i := 0; i < 8; i++ {
+		s.increment("old")
+	}
+	before := s.estimate("old")
+
+	// Other keys push the sketch past its sample size
+	for i, last := 0, 0; s.additions >= last; i++ {
+		last = s.additions
+		s.increment(i)
+	}
+
+	if got := s.estimate("old"); got >= before {
+		t.Errorf("Expected aging to lower the estimate below %d, got %d", before, got)
+	}
+}
+
+func TestSketchReset(t *testing.T) {
+	s := newSketch(10)
+
+	s.increment("a")
+	s.reset()
+
+	if s.estimate("a") != 0 || s.additions != 0 {
+		t.Error("reset should forget every count")
+	}
+}
@@ -0,0 +1,219 @@
+package tinylfu
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+const (
+	windowRatio    = 0.01 // share of the capacity given to the window LRU
+	protectedRatio = 0.8  // share of the main space given to the protected segment
+)
+
+// Cache W-TinyLFU cache structure.
+//
+// New keys enter a small window LRU. A key pushed out of the window is only admitted
+// to the main space, a segmented LRU, if the TinyLFU sketch estimates it is used more
+// often than the key the main space would evict. Main keys start in probation and
+// move to protected when hit again.
+type Cache struct {
+	capacity     int
+	windowCap    int
+	mainCap      int
+	protectedCap int
+	window       *list.List // most recently used first
+	probation    *list.List // main segment for keys not hit since admission
+	protected    *list.List // main segment for keys hit in probation
+	cache        map[any]*list.Element
+	sketch       *sketch
+	mutex        sync.RWMutex
+}
+
+// entry cache entry
+type entry struct {
+	key   any
+	value any
+	list  *list.List // the list holding the entry
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new W-TinyLFU cache
+func New(capacity int) *Cache {
+	windowCap := max(1, int(float64(capacity)*windowRatio))
+	mainCap := max(0, capacity-windowCap)
+	return &Cache{
+		capacity:     capacity,
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: int(float64(mainCap) * protectedRatio),
+		window:       list.New(),
+		probation:    list.New(),
+		protected:    list.New(),
+		cache:        make(map[any]*list.Element),
+		sketch:       newSketch(capacity),
+	}
+}
+
+// Get retrieves a value from the cache, every lookup counts towards the key's frequency
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sketch.increment(key)
+	if element, ok := c.cache[key]; ok {
+		c.touch(element)
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
+
+// Put adds a key-value pair to the cache. A new key may be rejected later when it
+// leaves the window, so it is not guaranteed to stay in the cache.
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.sketch.increment(key)
+	if element, ok := c.cache[key]; ok {
+		element.Value.(*entry).value = value
+		c.touch(element)
+		return
+	}
+
+	e := &entry{key: key, value: value, list: c.window}
+	c.cache[key] = c.window.PushFront(e)
+	if c.window.Len() > c.windowCap {
+		c.admit(c.window.Back())
+	}
+}
+
+// touch records a hit on a resident entry
+func (c *Cache) touch(element *list.Element) {
+	e := element.Value.(*entry)
+	switch e.list {
+	case c.window, c.protected:
+		e.list.MoveToFront(element)
+	case c.probation:
+		c.moveToFront(element, c.protected)
+		if c.protected.Len() > c.protectedCap {
+			c.moveToFront(c.protected.Back(), c.probation)
+		}
+	}
+}
+
+// admit moves the candidate pushed out of the window into probation, if the main
+// space has room or the candidate is estimated to be used more often than the victim
+func (c *Cache) admit(candidate *list.Element) {
+	if c.probation.Len()+c.protected.Len() < c.mainCap {
+		c.moveToFront(candidate, c.probation)
+		return
+	}
+
+	victim := c.probation.Back()
+	if victim == nil {
+		victim = c.protected.Back()
+	}
+	if victim == nil {
+		c.removeElement(candidate)
+		return
+	}
+
+	candidateKey := candidate.Value.(*entry).key
+	victimKey := victim.Value.(*entry).key
+	if c.sketch.estimate(candidateKey) > c.sketch.estimate(victimKey) {
+		c.removeElement(victim)
+		c.moveToFront(candidate, c.probation)
+	} else {
+		c.removeElement(candidate)
+	}
+}
+
+// moveToFront moves an element to the front of the target list
+func (c *Cache) moveToFront(element *list.Element, target *list.List) {
+	e := element.Value.(*entry)
+	e.list.Remove(element)
+	e.list = target
+	c.cache[e.key] = target.PushFront(e)
+}
+
+// removeElement removes a specific element
+func (c *Cache) removeElement(element *list.Element) {
+	e := element.Value.(*entry)
+	e.list.Remove(element)
+	delete(c.cache, e.key)
+}
+
+// Remove removes a key from the cache
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.cache[key]; ok {
+		c.removeElement(element)
+		return true
+	}
+	return false
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.cache)
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache and forgets all frequencies
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.window = list.New()
+	c.probation = list.New()
+	c.protected = list.New()
+	c.cache = make(map[any]*list.Element)
+	c.sketch.reset()
+}
+
+// Keys returns all keys in the cache: protected, then probation, then the window,
+// each most recently used first
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, len(c.cache))
+	for _, l := range []*list.List{c.protected, c.probation, c.window} {
+		for element := l.Front(); element != nil; element = element.Next() {
+			keys = append(keys, element.Value.(*entry).key)
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.cache[key]
+	return ok
+}
+
+// Peek looks up a value without updating the access order or frequency
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.cache[key]; ok {
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,326 @@
+package tinylfu
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+func TestTinyLFUCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, key2 is used less often than key1 and is not admitted
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key2"); ok {
+		t.Error("key2 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("key1 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestTinyLFUCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestTinyLFUCacheAdmission(t *testing.T) {
+	cache := New(100) // window 1, main 99
+
+	// Fill the main space with keys used a few times
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+		cache.Get(i)
+		cache.Get(i)
+	}
+
+	// Cold keys pass through the window but are not admitted. The sketch may
+	// overestimate a cold key now and then, so allow for a few collisions.
+	for i := 1000; i < 1100; i++ {
+		cache.Put(i, i)
+	}
+	kept := 0
+	for i := 0; i < 99; i++ {
+		if cache.Contains(i) {
+			kept++
+		}
+	}
+	if kept < 95 {
+		t.Fatalf("Frequent keys should not be replaced by cold keys, %d of 99 kept", kept)
+	}
+
+	// A key seen often enough wins against the victim
+	for i := 0; i < 5; i++ {
+		cache.Get("popular")
+	}
+	cache.Put("popular", 1)
+	cache.Put("next", 1) // pushes popular out of the window
+
+	if !cache.Contains("popular") {
+		t.Error("A popular key should be admitted to the main space")
+	}
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
+
+func TestTinyLFUCacheSegments(t *testing.T) {
+	cache := New(10) // window 1, main 9, protected 7
+
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+
+	// Keys pushed out of the window start in probation
+	if cache.probation.Len() != 4 || cache.window.Len() != 1 {
+		t.Fatalf("Expected 4 keys in probation and 1 in the window, got %d and %d",
+			cache.probation.Len(), cache.window.Len())
+	}
+
+	// A hit in probation promotes to protected
+	cache.Get(0)
+	if cache.cache[0].Value.(*entry).list != cache.protected {
+		t.Error("key 0 should have moved to protected")
+	}
+
+	keys := cache.Keys()
+	if keys[0] != 0 {
+		t.Errorf("Expected protected keys first, got %v", keys)
+	}
+}
+
+func TestTinyLFUCacheProtectedLimit(t *testing.T) {
+	cache := New(10) // protected holds at most 7
+
+	for i := 0; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	for i := 0; i < 10; i++ {
+		cache.Get(i)
+	}
+
+	if cache.protected.Len() > cache.protectedCap {
+		t.Errorf("Protected holds %d keys, limit %d", cache.protected.Len(), cache.protectedCap)
+	}
+	if cache.Len() != 10 {
+		t.Errorf("Expected length 10, got %d", cache.Len())
+	}
+}
+
+func TestTinyLFUCacheScanResistance(t *testing.T) {
+	tiny := New(100)
+	lruCache := lru.New(100)
+
+	hits := make(map[cache.Cache]int)
+	for _, c := range []cache.Cache{tiny, lruCache} {
+		// A popular working set is used again after every scan of 200 cold keys
+		for i := 0; i < 4000; i++ {
+			if i%200 == 0 {
+				for key := 0; key < 20; key++ {
+					if _, ok := c.Get(key); ok {
+						hits[c]++
+					} else {
+						c.Put(key, key)
+					}
+				}
+			}
+			if _, ok := c.Get(1000 + i); !ok {
+				c.Put(1000+i, i)
+			}
+		}
+	}
+
+	t.Logf("hot hits: W-TinyLFU %d, LRU %d", hits[tiny], hits[lruCache])
+	if hits[tiny] < 250 {
+		t.Errorf("Expected the popular keys to survive the scans, only %d of 380 hits", hits[tiny])
+	}
+	if hits[lruCache] != 0 {
+		t.Errorf("Expected every scan to flush LRU, got %d hits", hits[lruCache])
+	}
+}
+
+func TestTinyLFUCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+}
+
+func TestTinyLFUCachePeek(t *testing.T) {
+	cache := New(2)
+
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	// Peek should not count towards the frequency
+	for i := 0; i < 5; i++ {
+		if value, ok := cache.Peek("key1"); !ok || value != "value1" {
+			t.Errorf("Peek should return value1, got %v", value)
+		}
+	}
+	if cache.sketch.estimate("key1") != 1 {
+		t.Errorf("Peek should not change the frequency, got %d", cache.sketch.estimate("key1"))
+	}
+}
+
+func TestTinyLFUCacheClear(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+	if cache.sketch.estimate("a") != 0 {
+		t.Error("Clear should also forget the frequencies")
+	}
+}
+
+func TestTinyLFUCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestTinyLFUCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkTinyLFUCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkTinyLFUCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkTinyLFUCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestTinyLFUCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
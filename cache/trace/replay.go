@@ -0,0 +1,46 @@
+package trace
+
+import (
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Result outcome of replaying a trace
+type Result struct {
+	Requests uint64
+	Hits     uint64
+	Bytes    int64 // total size of all requests
+	HitBytes int64 // total size of the requests that hit
+}
+
+// HitRatio returns the fraction of requests that hit, zero for an empty trace
+func (r Result) HitRatio() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(r.Requests)
+}
+
+// ByteHitRatio returns the fraction of requested bytes that hit, zero for an empty trace
+func (r Result) ByteHitRatio() float64 {
+	if r.Bytes == 0 {
+		return 0
+	}
+	return float64(r.HitBytes) / float64(r.Bytes)
+}
+
+// Replay looks up every access in c, putting the key on a miss as a read-through
+// cache would, and counts the hits
+func Replay(c cache.Cache, accesses []Access) Result {
+	var result Result
+	for _, access := range accesses {
+		result.Requests++
+		result.Bytes += access.Size
+		if _, ok := c.Get(access.Key); ok {
+			result.Hits++
+			result.HitBytes += access.Size
+		} else {
+			c.Put(access.Key, access.Size)
+		}
+	}
+	return result
+}
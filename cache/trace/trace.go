@@ -0,0 +1,159 @@
+// Package trace reads cache access traces and replays them against cache.Cache
+// implementations to measure hit ratios.
+package trace
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format trace file format
+type Format int
+
+const (
+	// FormatPlain one key per line
+	FormatPlain Format = iota
+	// FormatARC lines of "start count ignored request" from the ARC paper traces,
+	// each line accesses the blocks start to start+count-1
+	FormatARC
+	// FormatLIRS one block number per line, as in the LIRS paper traces
+	FormatLIRS
+	// FormatCSV lines of "key,size", an optional header line is skipped
+	FormatCSV
+)
+
+// ErrUnknownFormat is returned by ParseFormat for an unsupported format name
+var ErrUnknownFormat = errors.New("trace: unknown format")
+
+// String returns the name of the format
+func (f Format) String() string {
+	switch f {
+	case FormatPlain:
+		return "plain"
+	case FormatARC:
+		return "arc"
+	case FormatLIRS:
+		return "lirs"
+	case FormatCSV:
+		return "csv"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range []Format{FormatPlain, FormatARC, FormatLIRS, FormatCSV} {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknownFormat, name)
+}
+
+// Access one request of a trace
+type Access struct {
+	Key  string
+	Size int64 // size of the object, 1 when the format has no sizes
+}
+
+// Read parses a whole trace. Blank lines and lines starting with '#' are skipped.
+func Read(r io.Reader, format Format) ([]Access, error) {
+	switch format {
+	case FormatPlain:
+		return readLines(r, readPlain)
+	case FormatARC:
+		return readLines(r, readARC)
+	case FormatLIRS:
+		return readLines(r, readLIRS)
+	case FormatCSV:
+		return readCSV(r)
+	default:
+		return nil, fmt.Errorf("%w %d", ErrUnknownFormat, int(format))
+	}
+}
+
+// readLines parses a line based trace, parse appends the accesses of one line
+func readLines(r io.Reader, parse func(accesses []Access, line string) ([]Access, error)) ([]Access, error) {
+	var accesses []Access
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var err error
+		if accesses, err = parse(accesses, line); err != nil {
+			return nil, fmt.Errorf("trace: line %d: %w", number, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("trace: %w", err)
+	}
+	return accesses, nil
+}
+
+func readPlain(accesses []Access, line string) ([]Access, error) {
+	return append(accesses, Access{Key: line, Size: 1}), nil
+}
+
+func readLIRS(accesses []Access, line string) ([]Access, error) {
+	if _, err := strconv.ParseInt(line, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid block number %q", line)
+	}
+	return append(accesses, Access{Key: line, Size: 1}), nil
+}
+
+func readARC(accesses []Access, line string) ([]Access, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("expected start and count, got %q", line)
+	}
+	start, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start block %q", fields[0])
+	}
+	count, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid block count %q", fields[1])
+	}
+	for block := start; block < start+count; block++ {
+		accesses = append(accesses, Access{Key: strconv.FormatInt(block, 10), Size: 1})
+	}
+	return accesses, nil
+}
+
+func readCSV(r io.Reader) ([]Access, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	var accesses []Access
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return accesses, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("trace: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 {
+			return nil, fmt.Errorf("trace: line %d: expected key and size, got %q", line, record)
+		}
+		size, err := strconv.ParseInt(strings.TrimSpace(record[1]), 10, 64)
+		if err != nil {
+			if first {
+				// A header line
+				continue
+			}
+			return nil, fmt.Errorf("trace: line %d: invalid size %q", line, record[1])
+		}
+		accesses = append(accesses, Access{Key: strings.TrimSpace(record[0]), Size: size})
+	}
+}
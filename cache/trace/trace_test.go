@@ -0,0 +1,153 @@
+package trace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+// keys returns the keys of the accesses in order
+func keys(accesses []Access) []string {
+	result := make([]string, len(accesses))
+	for i, access := range accesses {
+		result[i] = access.Key
+	}
+	return result
+}
+
+func TestReadPlain(t *testing.T) {
+	input := "a\n\n# comment\n  b  \na\n"
+
+	accesses, err := Read(strings.NewReader(input), FormatPlain)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	expected := []string{"a", "b", "a"}
+	if got := keys(accesses); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected keys %v, got %v", expected, got)
+	}
+	for _, access := range accesses {
+		if access.Size != 1 {
+			t.Errorf("Expected size 1 for %q, got %d", access.Key, access.Size)
+		}
+	}
+}
+
+func TestReadARC(t *testing.T) {
+	input := "10 3 0 1\n# comment\n7 1 0 2\n"
+
+	accesses, err := Read(strings.NewReader(input), FormatARC)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	// A line accesses count consecutive blocks
+	expected := "10,11,12,7"
+	if got := strings.Join(keys(accesses), ","); got != expected {
+		t.Errorf("Expected keys %s, got %s", expected, got)
+	}
+}
+
+func TestReadLIRS(t *testing.T) {
+	accesses, err := Read(strings.NewReader("1\n2\n1\n"), FormatLIRS)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if got := strings.Join(keys(accesses), ","); got != "1,2,1" {
+		t.Errorf("Expected keys 1,2,1, got %s", got)
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "key,size\n# comment\na,100\nb, 20\na,100\n"
+
+	accesses, err := Read(strings.NewReader(input), FormatCSV)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	expected := []Access{{"a", 100}, {"b", 20}, {"a", 100}}
+	if len(accesses) != len(expected) {
+		t.Fatalf("Expected %d accesses, got %v", len(expected), accesses)
+	}
+	for i := range expected {
+		if accesses[i] != expected[i] {
+			t.Errorf("Access %d: expected %v, got %v", i, expected[i], accesses[i])
+		}
+	}
+
+	// Without a header the first record is data
+	accesses, err = Read(strings.NewReader("a,1\n"), FormatCSV)
+	if err != nil || len(accesses) != 1 {
+		t.Errorf("Expected one access without a header, got %v, %v", accesses, err)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		format Format
+	}{
+		{"lirs not a number", "1\nx\n", FormatLIRS},
+		{"arc missing count", "10\n", FormatARC},
+		{"arc bad count", "10 0\n", FormatARC},
+		{"csv missing size", "a,1\nb\n", FormatCSV},
+		{"csv bad size after header", "key,size\na,big\n", FormatCSV},
+		{"unknown format", "a\n", Format(99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tt.input), tt.format); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	// Errors name the offending line
+	_, err := Read(strings.NewReader("1\n\nx\n"), FormatLIRS)
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Expected an error for line 3, got %v", err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range []Format{FormatPlain, FormatARC, FormatLIRS, FormatCSV} {
+		got, err := ParseFormat(strings.ToUpper(f.String()))
+		if err != nil || got != f {
+			t.Errorf("ParseFormat(%q) = %v, %v", f.String(), got, err)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	accesses := []Access{{"a", 10}, {"b", 30}, {"a", 10}, {"c", 50}, {"b", 30}, {"a", 10}}
+
+	// With room for two keys: a miss, b miss, a hit, c miss evicts b, b miss evicts a, a miss
+	result := Replay(lru.New(2), accesses)
+
+	if result.Requests != 6 || result.Hits != 1 {
+		t.Errorf("Expected 1 hit in 6 requests, got %d in %d", result.Hits, result.Requests)
+	}
+	if result.Bytes != 140 || result.HitBytes != 10 {
+		t.Errorf("Expected 10 of 140 bytes to hit, got %d of %d", result.HitBytes, result.Bytes)
+	}
+	if got := result.HitRatio(); got != 1.0/6 {
+		t.Errorf("Expected hit ratio 1/6, got %f", got)
+	}
+	if got := result.ByteHitRatio(); got != 10.0/140 {
+		t.Errorf("Expected byte hit ratio 10/140, got %f", got)
+	}
+
+	if empty := Replay(lru.New(2), nil); empty.HitRatio() != 0 || empty.ByteHitRatio() != 0 {
+		t.Error("An empty trace should have zero ratios")
+	}
+}
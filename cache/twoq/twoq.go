@@ -0,0 +1,232 @@
+package twoq
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+const (
+	// DefaultInRatio default share of the capacity given to A1in
+	DefaultInRatio = 0.25
+	// DefaultOutRatio default size of A1out relative to the capacity
+	DefaultOutRatio = 0.5
+)
+
+// Cache 2Q cache structure.
+//
+// New keys enter the FIFO queue A1in. Keys pushed out of A1in are remembered in the
+// ghost queue A1out, and a key seen again while in A1out is proven hot and goes to
+// the LRU list Am. A sequential scan only passes through A1in and A1out, so the hot
+// keys in Am survive it.
+type Cache struct {
+	capacity int
+	kin      int        // A1in is trimmed once it grows beyond kin
+	kout     int        // maximum number of ghosts in A1out
+	a1in     *list.List // resident, seen once, newest first
+	a1out    *list.List // ghosts pushed out of A1in, newest first
+	am       *list.List // resident, proven hot, most recently used first
+	cache    map[any]*list.Element
+	mutex    sync.RWMutex
+}
+
+// entry cache entry, ghosts keep the key only
+type entry struct {
+	key   any
+	value any
+	list  *list.List // the list holding the entry
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New creates a new 2Q cache with the default queue ratios
+func New(capacity int) *Cache {
+	return NewWithRatios(capacity, DefaultInRatio, DefaultOutRatio)
+}
+
+// NewWithRatios creates a new 2Q cache. inRatio is the share of the capacity
+// A1in may hold before it gives way to Am and must be within [0, 1].
+// outRatio sizes the ghost queue A1out relative to the capacity and must not
+// be negative. Ratios out of range fall back to the defaults.
+func NewWithRatios(capacity int, inRatio, outRatio float64) *Cache {
+	if inRatio < 0 || inRatio > 1 {
+		inRatio = DefaultInRatio
+	}
+	if outRatio < 0 {
+		outRatio = DefaultOutRatio
+	}
+	return &Cache{
+		capacity: capacity,
+		kin:      int(float64(capacity) * inRatio),
+		kout:     int(float64(capacity) * outRatio),
+		a1in:     list.New(),
+		a1out:    list.New(),
+		am:       list.New(),
+		cache:    make(map[any]*list.Element),
+	}
+}
+
+// Get retrieves a value from the cache, keys in Am become the most recently used
+func (c *Cache) Get(key any) (any, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.resident(key)
+	if !ok {
+		return nil, false
+	}
+	e := element.Value.(*entry)
+	if e.list == c.am {
+		c.am.MoveToFront(element)
+	}
+	// A1in is a FIFO, a hit there does not change the order
+	return e.value, true
+}
+
+// Put adds a key-value pair to the cache
+func (c *Cache) Put(key, value any) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.cache[key]
+	if ok {
+		e := element.Value.(*entry)
+		switch e.list {
+		case c.am:
+			e.value = value
+			c.am.MoveToFront(element)
+			return
+		case c.a1in:
+			e.value = value
+			return
+		}
+		// Seen again after leaving A1in: the key is hot
+		c.removeElement(element)
+		c.reclaim()
+		e = &entry{key: key, value: value, list: c.am}
+		c.cache[key] = c.am.PushFront(e)
+		return
+	}
+
+	c.reclaim()
+	e := &entry{key: key, value: value, list: c.a1in}
+	c.cache[key] = c.a1in.PushFront(e)
+}
+
+// reclaim frees a slot if the cache is full. A1in gives way first while it is over
+// its share, its oldest key is remembered in A1out; otherwise the least recently
+// used key of Am is dropped.
+func (c *Cache) reclaim() {
+	if c.a1in.Len()+c.am.Len() < c.capacity {
+		return
+	}
+	if c.a1in.Len() > 0 && (c.a1in.Len() > c.kin || c.am.Len() == 0) {
+		oldest := c.a1in.Back()
+		e := oldest.Value.(*entry)
+		c.a1in.Remove(oldest)
+		if c.kout == 0 {
+			delete(c.cache, e.key)
+			return
+		}
+		e.value = nil
+		e.list = c.a1out
+		c.cache[e.key] = c.a1out.PushFront(e)
+		if c.a1out.Len() > c.kout {
+			c.removeElement(c.a1out.Back())
+		}
+		return
+	}
+	if oldest := c.am.Back(); oldest != nil {
+		c.removeElement(oldest)
+	}
+}
+
+// removeElement removes a specific element
+func (c *Cache) removeElement(element *list.Element) {
+	e := element.Value.(*entry)
+	e.list.Remove(element)
+	delete(c.cache, e.key)
+}
+
+// resident looks up a key in A1in or Am
+func (c *Cache) resident(key any) (*list.Element, bool) {
+	element, ok := c.cache[key]
+	if !ok || element.Value.(*entry).list == c.a1out {
+		return nil, false
+	}
+	return element, true
+}
+
+// Remove removes a key from the cache and forgets its history
+func (c *Cache) Remove(key any) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.cache[key]
+	if !ok {
+		return false
+	}
+	_, resident := c.resident(key)
+	c.removeElement(element)
+	return resident
+}
+
+// Len returns the number of elements in the cache
+func (c *Cache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.a1in.Len() + c.am.Len()
+}
+
+// Cap returns the capacity of the cache
+func (c *Cache) Cap() int {
+	// Capacity doesn't change, no lock needed
+	return c.capacity
+}
+
+// Clear removes all elements from the cache
+func (c *Cache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.a1in = list.New()
+	c.a1out = list.New()
+	c.am = list.New()
+	c.cache = make(map[any]*list.Element)
+}
+
+// Keys returns all keys in the cache, the hot keys of Am first, most recently used
+// first, and then the keys of A1in, newest first
+func (c *Cache) Keys() []any {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]any, 0, c.a1in.Len()+c.am.Len())
+	for _, l := range []*list.List{c.am, c.a1in} {
+		for element := l.Front(); element != nil; element = element.Next() {
+			keys = append(keys, element.Value.(*entry).key)
+		}
+	}
+	return keys
+}
+
+// Contains checks if the cache contains a specific key
+func (c *Cache) Contains(key any) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	_, ok := c.resident(key)
+	return ok
+}
+
+// Peek looks up a value without updating the access order
+func (c *Cache) Peek(key any) (any, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if element, ok := c.resident(key); ok {
+		return element.Value.(*entry).value, true
+	}
+	return nil, false
+}
@@ -0,0 +1,332 @@
+package twoq
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+	"github.com/loveRyujin/go-algorithm/cache/cachetest"
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+func TestTwoQCache(t *testing.T) {
+	cache := New(2)
+
+	// Test basic Put and Get operations
+	cache.Put("key1", "value1")
+	cache.Put("key2", "value2")
+
+	if value, ok := cache.Get("key1"); !ok || value != "value1" {
+		t.Errorf("Expected value1, got %v", value)
+	}
+
+	// Test capacity limit, A1in is a FIFO so key1 goes first despite the Get
+	cache.Put("key3", "value3")
+
+	if _, ok := cache.Get("key1"); ok {
+		t.Error("key1 should have been evicted")
+	}
+
+	if value, ok := cache.Get("key2"); !ok || value != "value2" {
+		t.Errorf("key2 should still be in cache, got %v", value)
+	}
+
+	if value, ok := cache.Get("key3"); !ok || value != "value3" {
+		t.Errorf("key3 should be in cache, got %v", value)
+	}
+}
+
+func TestTwoQCacheUpdate(t *testing.T) {
+	cache := New(2)
+
+	// Test updating existing key
+	cache.Put("key1", "value1")
+	cache.Put("key1", "updated_value1")
+
+	if value, ok := cache.Get("key1"); !ok || value != "updated_value1" {
+		t.Errorf("Expected updated_value1, got %v", value)
+	}
+
+	// Cache should still have space
+	if cache.Len() != 1 {
+		t.Errorf("Expected length 1, got %d", cache.Len())
+	}
+}
+
+func TestTwoQCachePromotion(t *testing.T) {
+	cache := New(4) // kin = 1, kout = 2
+
+	for i := 0; i < 6; i++ {
+		cache.Put(i, i)
+	}
+
+	// 0 and 1 were pushed out of A1in and are remembered in A1out
+	if cache.Contains(0) || cache.Contains(1) {
+		t.Fatal("keys 0 and 1 should have been evicted")
+	}
+	if cache.a1out.Len() != 2 {
+		t.Fatalf("Expected 2 ghosts in A1out, got %d", cache.a1out.Len())
+	}
+
+	// Seen again while in A1out: straight into Am
+	cache.Put(1, "hot")
+
+	element, ok := cache.resident(1)
+	if !ok || element.Value.(*entry).list != cache.am {
+		t.Fatal("key 1 should be in Am")
+	}
+	if value, _ := cache.Get(1); value != "hot" {
+		t.Errorf("Expected hot, got %v", value)
+	}
+	if cache.Len() != 4 {
+		t.Errorf("Expected length 4, got %d", cache.Len())
+	}
+
+	keys := cache.Keys()
+	if keys[0] != 1 {
+		t.Errorf("Expected keys of Am first, got %v", keys)
+	}
+}
+
+func TestTwoQCacheGhostLimit(t *testing.T) {
+	cache := New(4) // kout = 2
+
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+		if cache.a1out.Len() > 2 {
+			t.Fatalf("A1out holds %d ghosts, want at most 2", cache.a1out.Len())
+		}
+		if len(cache.cache) != cache.a1in.Len()+cache.a1out.Len()+cache.am.Len() {
+			t.Fatal("Map and queues disagree")
+		}
+	}
+
+	// Only the newest ghosts are remembered
+	cache.Put(0, 0)
+	if element, _ := cache.resident(0); element.Value.(*entry).list != cache.a1in {
+		t.Error("A forgotten key should start over in A1in")
+	}
+}
+
+func TestTwoQCacheScanResistance(t *testing.T) {
+	twoq := New(10)
+	lruCache := lru.New(10)
+
+	for _, c := range []cache.Cache{twoq, lruCache} {
+		// The hot keys are used, pushed out by other traffic and used again
+		for i := 0; i < 5; i++ {
+			c.Put(i, i)
+		}
+		for i := 100; i < 110; i++ {
+			c.Put(i, i)
+		}
+		for i := 0; i < 5; i++ {
+			if _, ok := c.Get(i); !ok {
+				c.Put(i, i)
+			}
+		}
+
+		// A sequential scan over many cold keys
+		for i := 1000; i < 2000; i++ {
+			if _, ok := c.Get(i); !ok {
+				c.Put(i, i)
+			}
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		if !twoq.Contains(i) {
+			t.Errorf("Hot key %d should survive the scan", i)
+		}
+	}
+
+	// The same trace flushes the hot set out of an LRU cache
+	for i := 0; i < 5; i++ {
+		if lruCache.Contains(i) {
+			t.Errorf("LRU unexpectedly kept hot key %d", i)
+		}
+	}
+}
+
+func TestTwoQCacheRatios(t *testing.T) {
+	cache := NewWithRatios(10, 0.5, 1)
+
+	if cache.kin != 5 || cache.kout != 10 {
+		t.Errorf("Expected kin=5 kout=10, got kin=%d kout=%d", cache.kin, cache.kout)
+	}
+
+	// Ratios out of range fall back to the defaults
+	cache = NewWithRatios(10, 2, -1)
+	if cache.kin != 2 || cache.kout != 5 {
+		t.Errorf("Expected default kin=2 kout=5, got kin=%d kout=%d", cache.kin, cache.kout)
+	}
+
+	// Without a ghost queue no key is ever promoted
+	cache = NewWithRatios(2, 0.5, 0)
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 4; i++ {
+			cache.Put(i, i)
+		}
+	}
+	if cache.am.Len() != 0 || cache.a1out.Len() != 0 {
+		t.Errorf("Expected empty Am and A1out, got %d and %d", cache.am.Len(), cache.a1out.Len())
+	}
+}
+
+func TestTwoQCacheRemove(t *testing.T) {
+	cache := New(3)
+
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+
+	// Test removing existing key
+	if !cache.Remove("b") {
+		t.Error("Remove should return true for existing key")
+	}
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("key b should have been removed")
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", cache.Len())
+	}
+
+	// Test removing non-existing key
+	if cache.Remove("d") {
+		t.Error("Remove should return false for non-existing key")
+	}
+}
+
+func TestTwoQCachePeek(t *testing.T) {
+	cache := New(4)
+
+	for i := 0; i < 6; i++ {
+		cache.Put(i, i)
+	}
+	cache.Put(1, 1)
+	cache.Put(5, 5)
+
+	// Peek should not refresh keys in Am
+	if value, ok := cache.Peek(1); !ok || value != 1 {
+		t.Errorf("Peek should return 1, got %v", value)
+	}
+	if keys := cache.Keys(); keys[0] != 1 {
+		t.Errorf("Expected 1 first, got %v", keys)
+	}
+}
+
+func TestTwoQCacheClear(t *testing.T) {
+	cache := New(3)
+
+	for i := 0; i < 6; i++ {
+		cache.Put(i, i)
+	}
+
+	cache.Clear()
+
+	if cache.Len() != 0 {
+		t.Errorf("Expected length 0 after clear, got %d", cache.Len())
+	}
+	if cache.a1out.Len() != 0 || len(cache.cache) != 0 {
+		t.Error("Clear should also forget the ghosts")
+	}
+}
+
+func TestTwoQCacheCapacity(t *testing.T) {
+	cache := New(5)
+
+	if cache.Cap() != 5 {
+		t.Errorf("Expected capacity 5, got %d", cache.Cap())
+	}
+
+	// Adding elements should not change capacity
+	cache.Put("key", "value")
+	if cache.Cap() != 5 {
+		t.Errorf("Capacity should remain 5, got %d", cache.Cap())
+	}
+}
+
+func TestTwoQCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(capacity int) cache.Cache { return New(capacity) })
+}
+
+// Benchmark tests
+func BenchmarkTwoQCachePut(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Put(i, i)
+	}
+}
+
+func BenchmarkTwoQCacheGet(b *testing.B) {
+	cache := New(1000)
+
+	// Pre-populate cache
+	for i := 0; i < 1000; i++ {
+		cache.Put(i, i)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cache.Get(i % 1000)
+	}
+}
+
+func BenchmarkTwoQCacheMixed(b *testing.B) {
+	cache := New(1000)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if i%2 == 0 {
+			cache.Put(i, i)
+		} else {
+			cache.Get(i % 1000)
+		}
+	}
+}
+
+// Test concurrent access to ensure thread safety
+func TestTwoQCacheConcurrency(t *testing.T) {
+	cache := New(100)
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := (id*100 + j) % 300
+				cache.Put(key, key)
+				cache.Get(key)
+				cache.Peek(key)
+				cache.Contains(key)
+				if j%10 == 0 {
+					cache.Remove(key)
+				}
+			}
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cache.Len()
+			cache.Keys()
+			if i%20 == 0 {
+				cache.Clear()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if cache.Len() > cache.Cap() {
+		t.Errorf("Cache length %d exceeds capacity %d", cache.Len(), cache.Cap())
+	}
+}
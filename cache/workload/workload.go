@@ -0,0 +1,178 @@
+// Package workload generates synthetic key streams for cache benchmarks and
+// hit-ratio comparisons. Every generator is deterministic for a given seed.
+package workload
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Generator produces an endless stream of keys in [0, key space).
+// Generators are not safe for concurrent use.
+type Generator interface {
+	Next() int
+}
+
+// Keys returns the next n keys of g
+func Keys(g Generator, n int) []int {
+	keys := make([]int, n)
+	for i := range keys {
+		keys[i] = g.Next()
+	}
+	return keys
+}
+
+// newRand creates the random source of a generator
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func checkKeySpace(keySpace int) {
+	if keySpace < 1 {
+		panic(fmt.Sprintf("workload: key space must be positive, got %d", keySpace))
+	}
+}
+
+// Zipf draws keys following a Zipf distribution, key 0 is the most popular
+type Zipf struct {
+	zipf *rand.Zipf
+}
+
+// NewZipf creates a Zipf generator over keySpace keys with exponent s > 1,
+// a larger s concentrates more requests on the most popular keys
+func NewZipf(seed uint64, keySpace int, s float64) *Zipf {
+	checkKeySpace(keySpace)
+	if s <= 1 {
+		panic(fmt.Sprintf("workload: Zipf exponent must be greater than 1, got %g", s))
+	}
+	return &Zipf{zipf: rand.NewZipf(newRand(seed), s, 1, uint64(keySpace-1))}
+}
+
+// Next returns the next key
+func (g *Zipf) Next() int {
+	return int(g.zipf.Uint64())
+}
+
+// Uniform draws every key with the same probability
+type Uniform struct {
+	rand     *rand.Rand
+	keySpace int
+}
+
+// NewUniform creates a uniform generator over keySpace keys
+func NewUniform(seed uint64, keySpace int) *Uniform {
+	checkKeySpace(keySpace)
+	return &Uniform{rand: newRand(seed), keySpace: keySpace}
+}
+
+// Next returns the next key
+func (g *Uniform) Next() int {
+	return g.rand.IntN(g.keySpace)
+}
+
+// Sequential walks a range of keys in order and starts again at the end
+type Sequential struct {
+	length int
+	next   int
+}
+
+// NewSequential creates a sequential scan over keySpace keys. Every key is seen
+// once per pass, so a recency based cache smaller than the key space never hits.
+func NewSequential(keySpace int) *Sequential {
+	checkKeySpace(keySpace)
+	return &Sequential{length: keySpace}
+}
+
+// NewLoop creates a loop over the first length keys. Unlike a scan over a large key
+// space the loop is meant to be slightly larger than the cache: LRU then always
+// evicts the key needed next while MRU or ARC keep part of the loop.
+func NewLoop(length int) *Sequential {
+	return NewSequential(length)
+}
+
+// Next returns the next key
+func (g *Sequential) Next() int {
+	key := g.next
+	g.next = (g.next + 1) % g.length
+	return key
+}
+
+// Hotspot sends a fixed share of the requests to a small hot range of keys
+type Hotspot struct {
+	rand           *rand.Rand
+	keySpace       int
+	hotKeys        int
+	hotProbability float64
+	offset         int // first hot key
+	period         int // requests between shifts, 0 never shifts
+	requests       int
+}
+
+// NewHotspot creates a generator where the first hotFraction of keySpace receives
+// hotProbability of the requests, both uniform within their range. For example a
+// fraction of 0.2 and a probability of 0.8 is the classic 80/20 rule.
+func NewHotspot(seed uint64, keySpace int, hotFraction, hotProbability float64) *Hotspot {
+	checkKeySpace(keySpace)
+	if hotFraction <= 0 || hotFraction > 1 || hotProbability < 0 || hotProbability > 1 {
+		panic(fmt.Sprintf("workload: invalid hotspot fraction %g or probability %g", hotFraction, hotProbability))
+	}
+	return &Hotspot{
+		rand:           newRand(seed),
+		keySpace:       keySpace,
+		hotKeys:        max(1, int(hotFraction*float64(keySpace))),
+		hotProbability: hotProbability,
+	}
+}
+
+// NewShiftingHotspot creates a hotspot that moves to the next hotFraction of the key
+// space every period requests, wrapping around, so the popular keys change over time
+// as they do when a new item goes viral
+func NewShiftingHotspot(seed uint64, keySpace int, hotFraction, hotProbability float64, period int) *Hotspot {
+	if period < 1 {
+		panic(fmt.Sprintf("workload: hotspot period must be positive, got %d", period))
+	}
+	g := NewHotspot(seed, keySpace, hotFraction, hotProbability)
+	g.period = period
+	return g
+}
+
+// Next returns the next key
+func (g *Hotspot) Next() int {
+	if g.period > 0 {
+		if g.requests == g.period {
+			g.requests = 0
+			g.offset = (g.offset + g.hotKeys) % g.keySpace
+		}
+		g.requests++
+	}
+
+	var key int
+	if coldKeys := g.keySpace - g.hotKeys; coldKeys == 0 || g.rand.Float64() < g.hotProbability {
+		key = g.rand.IntN(g.hotKeys)
+	} else {
+		key = g.hotKeys + g.rand.IntN(coldKeys)
+	}
+	return (g.offset + key) % g.keySpace
+}
+
+// Workload a named generator constructor, for table driven benchmarks
+type Workload struct {
+	Name string
+	New  func(seed uint64) Generator
+}
+
+// Standard returns one workload of each kind over keySpace keys:
+// Zipf with s=1.1, uniform, a sequential scan, an 80/20 hotspot, a 90/10 hotspot
+// shifting every keySpace requests and a loop over a fifth of the keys
+func Standard(keySpace int) []Workload {
+	return []Workload{
+		{"zipf", func(seed uint64) Generator { return NewZipf(seed, keySpace, 1.1) }},
+		{"uniform", func(seed uint64) Generator { return NewUniform(seed, keySpace) }},
+		{"sequential", func(uint64) Generator { return NewSequential(keySpace) }},
+		{"hotspot", func(seed uint64) Generator { return NewHotspot(seed, keySpace, 0.2, 0.8) }},
+		{"shifting-hotspot", func(seed uint64) Generator {
+			return NewShiftingHotspot(seed, keySpace, 0.1, 0.9, keySpace)
+		}},
+		{"loop", func(uint64) Generator { return NewLoop(max(1, keySpace/5)) }},
+	}
+}
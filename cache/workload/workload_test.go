@@ -0,0 +1,115 @@
+package workload
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache/lru"
+)
+
+func TestGeneratorsStayInKeySpace(t *testing.T) {
+	const keySpace = 1000
+
+	for _, w := range Standard(keySpace) {
+		t.Run(w.Name, func(t *testing.T) {
+			for _, key := range Keys(w.New(1), 10000) {
+				if key < 0 || key >= keySpace {
+					t.Fatalf("Key %d outside [0, %d)", key, keySpace)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorsAreDeterministic(t *testing.T) {
+	for _, w := range Standard(1000) {
+		t.Run(w.Name, func(t *testing.T) {
+			if !slices.Equal(Keys(w.New(7), 1000), Keys(w.New(7), 1000)) {
+				t.Error("The same seed should give the same keys")
+			}
+		})
+	}
+
+	if slices.Equal(Keys(NewUniform(1, 1000), 100), Keys(NewUniform(2, 1000), 100)) {
+		t.Error("Different seeds should give different keys")
+	}
+}
+
+func TestZipf(t *testing.T) {
+	counts := make([]int, 1000)
+	for _, key := range Keys(NewZipf(1, 1000, 1.1), 100000) {
+		counts[key]++
+	}
+
+	// Popularity falls with the rank of the key
+	if counts[0] <= counts[1] || counts[1] <= counts[10] || counts[10] <= counts[500] {
+		t.Errorf("Expected counts to fall with rank, got %d, %d, %d, %d",
+			counts[0], counts[1], counts[10], counts[500])
+	}
+}
+
+func TestSequentialAndLoop(t *testing.T) {
+	if got := Keys(NewSequential(3), 7); !slices.Equal(got, []int{0, 1, 2, 0, 1, 2, 0}) {
+		t.Errorf("Unexpected sequential keys %v", got)
+	}
+
+	// A loop just larger than an LRU cache never hits
+	cache := lru.New(10)
+	for _, key := range Keys(NewLoop(11), 1000) {
+		if _, ok := cache.Get(key); ok {
+			t.Fatalf("LRU should miss every key of a loop larger than the cache, hit %d", key)
+		}
+		cache.Put(key, key)
+	}
+}
+
+func TestHotspot(t *testing.T) {
+	hot := 0
+	for _, key := range Keys(NewHotspot(1, 1000, 0.2, 0.8), 100000) {
+		if key < 200 {
+			hot++
+		}
+	}
+
+	if hot < 79000 || hot > 81000 {
+		t.Errorf("Expected about 80%% of the requests on the hot keys, got %d of 100000", hot)
+	}
+}
+
+func TestShiftingHotspot(t *testing.T) {
+	g := NewShiftingHotspot(1, 1000, 0.1, 1, 50)
+
+	// Every request goes to the current hot range, which moves every 50 requests
+	for period := 0; period < 25; period++ {
+		start := (period * 100) % 1000
+		for _, key := range Keys(g, 50) {
+			if key < start || key >= start+100 {
+				t.Fatalf("Period %d: key %d outside the hot range [%d, %d)", period, key, start, start+100)
+			}
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	tests := []struct {
+		name string
+		new  func()
+	}{
+		{"zero key space", func() { NewUniform(1, 0) }},
+		{"zipf exponent", func() { NewZipf(1, 100, 1) }},
+		{"hot fraction", func() { NewHotspot(1, 100, 0, 0.5) }},
+		{"hot probability", func() { NewHotspot(1, 100, 0.1, 2) }},
+		{"period", func() { NewShiftingHotspot(1, 100, 0.1, 0.9, 0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected a panic")
+				}
+			}()
+			tt.new()
+		})
+	}
+}
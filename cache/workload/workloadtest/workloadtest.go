@@ -0,0 +1,45 @@
+// Package workloadtest replays synthetic workloads in benchmarks. It is kept apart
+// from package workload so that importing the generators does not link testing.
+package workloadtest
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/loveRyujin/go-algorithm/cache"
+)
+
+// Benchmark replays keys, for example from workload.Keys, against c from b.RunParallel as a read-through cache,
+// putting every key that misses, and reports the hit ratio as "hit%". Each
+// goroutine starts at a different position of keys and wraps around at the end.
+func Benchmark(b *testing.B, c cache.Cache, keys []int) {
+	// Box the keys up front so the conversion is not measured
+	boxed := make([]any, len(keys))
+	for i, key := range keys {
+		boxed[i] = key
+	}
+
+	var requests, hits, workers atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(workers.Add(1)) * 7919
+		var localRequests, localHits int64
+		for pb.Next() {
+			key := boxed[i%len(boxed)]
+			if _, ok := c.Get(key); ok {
+				localHits++
+			} else {
+				c.Put(key, key)
+			}
+			localRequests++
+			i++
+		}
+		requests.Add(localRequests)
+		hits.Add(localHits)
+	})
+	b.StopTimer()
+
+	if n := requests.Load(); n > 0 {
+		b.ReportMetric(float64(hits.Load())/float64(n)*100, "hit%")
+	}
+}